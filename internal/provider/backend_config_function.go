@@ -0,0 +1,98 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &BackendConfigFunction{}
+
+func NewBackendConfigFunction() function.Function {
+	return &BackendConfigFunction{}
+}
+
+// BackendConfigFunction defines the function implementation.
+type BackendConfigFunction struct{}
+
+// BackendConfigFunctionModel describes the function result data model.
+type BackendConfigFunctionModel struct {
+	Address       types.String `tfsdk:"address"`
+	LockAddress   types.String `tfsdk:"lock_address"`
+	UnlockAddress types.String `tfsdk:"unlock_address"`
+	LockMethod    types.String `tfsdk:"lock_method"`
+	UnlockMethod  types.String `tfsdk:"unlock_method"`
+}
+
+func (f *BackendConfigFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "backend_config"
+}
+
+func (f *BackendConfigFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Terraform HTTP backend settings of a Lynx environment",
+		MarkdownDescription: "Returns the `address`, `lock_address`, `unlock_address`, `lock_method` and `unlock_method` " +
+			"settings of the Terraform HTTP backend storing the state of a Lynx environment.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "api_url",
+				MarkdownDescription: "Lynx API URL like `http://localhost:4000/api/v1`",
+			},
+			function.StringParameter{
+				Name:                "team_slug",
+				MarkdownDescription: "Team's slug",
+			},
+			function.StringParameter{
+				Name:                "project_slug",
+				MarkdownDescription: "Project's slug",
+			},
+			function.StringParameter{
+				Name:                "environment_slug",
+				MarkdownDescription: "Environment's slug",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"address":        types.StringType,
+				"lock_address":   types.StringType,
+				"unlock_address": types.StringType,
+				"lock_method":    types.StringType,
+				"unlock_method":  types.StringType,
+			},
+		},
+	}
+}
+
+func (f *BackendConfigFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var apiURL, teamSlug, projectSlug, environmentSlug string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &apiURL, &teamSlug, &projectSlug, &environmentSlug))
+
+	if resp.Error != nil {
+		return
+	}
+
+	backend, err := sdk.BackendConfig(apiURL, teamSlug, projectSlug, environmentSlug)
+
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, BackendConfigFunctionModel{
+		Address:       types.StringValue(backend.Address),
+		LockAddress:   types.StringValue(backend.LockAddress),
+		UnlockAddress: types.StringValue(backend.UnlockAddress),
+		LockMethod:    types.StringValue(backend.LockMethod),
+		UnlockMethod:  types.StringValue(backend.UnlockMethod),
+	}))
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccBackendConfigFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Provider defined functions are supported since Terraform 1.8
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "backend" {
+  value = provider::lynx::backend_config("http://localhost:4000/api/v1/", "clivern", "monitoring", "prod")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("backend", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"address":        knownvalue.StringExact("http://localhost:4000/client/clivern/monitoring/prod/state"),
+						"lock_address":   knownvalue.StringExact("http://localhost:4000/client/clivern/monitoring/prod/lock"),
+						"unlock_address": knownvalue.StringExact("http://localhost:4000/client/clivern/monitoring/prod/unlock"),
+						"lock_method":    knownvalue.StringExact("POST"),
+						"unlock_method":  knownvalue.StringExact("POST"),
+					})),
+				},
+			},
+			{
+				Config: `
+output "backend" {
+  value = provider::lynx::backend_config("localhost", "clivern", "monitoring", "prod")
+}
+`,
+				ExpectError: regexp.MustCompile("invalid API URL"),
+			},
+		},
+	})
+}
@@ -0,0 +1,254 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &EnvironmentCredentialsEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &EnvironmentCredentialsEphemeralResource{}
+
+func NewEnvironmentCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &EnvironmentCredentialsEphemeralResource{}
+}
+
+// EnvironmentCredentialsEphemeralResource defines the ephemeral resource implementation.
+type EnvironmentCredentialsEphemeralResource struct {
+	client sdk.LynxAPI
+}
+
+// EnvironmentCredentialsEphemeralResourceModel describes the ephemeral resource data model.
+type EnvironmentCredentialsEphemeralResourceModel struct {
+	ProjectID       types.String `tfsdk:"project_id"`
+	EnvironmentID   types.String `tfsdk:"environment_id"`
+	ProjectSlug     types.String `tfsdk:"project_slug"`
+	EnvironmentSlug types.String `tfsdk:"environment_slug"`
+	Username        types.String `tfsdk:"username"`
+	Secret          types.String `tfsdk:"secret"`
+	Address         types.String `tfsdk:"address"`
+	LockAddress     types.String `tfsdk:"lock_address"`
+	UnlockAddress   types.String `tfsdk:"unlock_address"`
+	LockMethod      types.String `tfsdk:"lock_method"`
+	UnlockMethod    types.String `tfsdk:"unlock_method"`
+}
+
+func (r *EnvironmentCredentialsEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_credentials"
+}
+
+func (r *EnvironmentCredentialsEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Credentials and HTTP backend settings of an environment. They are only available during the run and never stored in the plan or state. " +
+			"The environment is looked up by `project_id` and `environment_id` or by `project_slug` and `environment_slug`",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project identifier",
+				Optional:            true,
+				Computed:            true,
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier",
+				Optional:            true,
+				Computed:            true,
+			},
+			"project_slug": schema.StringAttribute{
+				MarkdownDescription: "Project's slug",
+				Optional:            true,
+				Computed:            true,
+			},
+			"environment_slug": schema.StringAttribute{
+				MarkdownDescription: "Environment's slug",
+				Optional:            true,
+				Computed:            true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Environment's username",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "Environment's secret",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "State address of the HTTP backend",
+				Computed:            true,
+			},
+			"lock_address": schema.StringAttribute{
+				MarkdownDescription: "Lock address of the HTTP backend",
+				Computed:            true,
+			},
+			"unlock_address": schema.StringAttribute{
+				MarkdownDescription: "Unlock address of the HTTP backend",
+				Computed:            true,
+			},
+			"lock_method": schema.StringAttribute{
+				MarkdownDescription: "Lock method of the HTTP backend",
+				Computed:            true,
+			},
+			"unlock_method": schema.StringAttribute{
+				MarkdownDescription: "Unlock method of the HTTP backend",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *EnvironmentCredentialsEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(sdk.LynxAPI)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected sdk.LynxAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *EnvironmentCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data EnvironmentCredentialsEphemeralResourceModel
+
+	// Read Terraform config data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	byID := data.ProjectID.ValueString() != "" && data.EnvironmentID.ValueString() != ""
+	bySlug := data.ProjectSlug.ValueString() != "" && data.EnvironmentSlug.ValueString() != ""
+
+	if byID == bySlug {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("environment_id"),
+			"Invalid Environment Lookup",
+			"Set either project_id and environment_id, or project_slug and environment_slug.",
+		)
+		return
+	}
+
+	var project *sdk.Project
+	var environment *sdk.Environment
+	var err error
+
+	if byID {
+		project, environment, err = r.findByID(ctx, data.ProjectID.ValueString(), data.EnvironmentID.ValueString())
+	} else {
+		project, environment, err = r.findBySlug(ctx, data.ProjectSlug.ValueString(), data.EnvironmentSlug.ValueString())
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read environment, got error: %s", err.Error()),
+		)
+		return
+	}
+
+	// Projects embed their team, only old servers need the extra lookup
+	if project.Team.Slug == "" {
+		team, err := r.client.GetTeam(ctx, project.Team.ID)
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to read team, got error: %s", err.Error()),
+			)
+			return
+		}
+
+		project.Team = *team
+	}
+
+	backend, err := r.client.Backend(project.Team.Slug, project.Slug, environment.Slug)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to build the environment backend, got error: %s", err.Error()),
+		)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Opened credentials of environment with id %s", environment.ID))
+
+	data.ProjectID = types.StringValue(project.ID)
+	data.EnvironmentID = types.StringValue(environment.ID)
+	data.ProjectSlug = types.StringValue(project.Slug)
+	data.EnvironmentSlug = types.StringValue(environment.Slug)
+	data.Username = types.StringValue(environment.Username)
+	data.Secret = types.StringValue(environment.Secret)
+	data.Address = types.StringValue(backend.Address)
+	data.LockAddress = types.StringValue(backend.LockAddress)
+	data.UnlockAddress = types.StringValue(backend.UnlockAddress)
+	data.LockMethod = types.StringValue(backend.LockMethod)
+	data.UnlockMethod = types.StringValue(backend.UnlockMethod)
+
+	// Save data into the ephemeral result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// findByID gets the environment and its project by their identifiers
+func (r *EnvironmentCredentialsEphemeralResource) findByID(ctx context.Context, projectId, environmentId string) (*sdk.Project, *sdk.Environment, error) {
+	project, err := r.client.GetProject(ctx, projectId)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	environment, err := r.client.GetEnvironment(ctx, projectId, environmentId)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return project, environment, nil
+}
+
+// findBySlug finds the environment and its project by their slugs
+func (r *EnvironmentCredentialsEphemeralResource) findBySlug(ctx context.Context, projectSlug, environmentSlug string) (*sdk.Project, *sdk.Environment, error) {
+	projects, err := sdk.IterateProjects(r.client, sdk.ListOptions{Slug: projectSlug}).All(ctx)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(projects) != 1 {
+		return nil, nil, fmt.Errorf("expected one project with slug %s, found %d", projectSlug, len(projects))
+	}
+
+	environments, err := sdk.IterateEnvironments(r.client, projects[0].ID, sdk.ListOptions{Slug: environmentSlug}).All(ctx)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(environments) != 1 {
+		return nil, nil, fmt.Errorf("expected one environment with slug %s in project %s, found %d", environmentSlug, projectSlug, len(environments))
+	}
+
+	return &projects[0], &environments[0], nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccEnvironmentCredentialsEphemeralResource(t *testing.T) {
+	server := testAccServer(t)
+	prefix := server.URL + "/client/monitoring/grafana/dev"
+
+	// The echo provider copies the ephemeral values into its state, so the
+	// test can check them
+	expected := knownvalue.ObjectExact(map[string]knownvalue.Check{
+		"project_id":       knownvalue.NotNull(),
+		"environment_id":   knownvalue.NotNull(),
+		"project_slug":     knownvalue.StringExact("grafana"),
+		"environment_slug": knownvalue.StringExact("dev"),
+		"username":         knownvalue.StringExact("admin"),
+		"secret":           knownvalue.StringExact("~secret-here~"),
+		"address":          knownvalue.StringExact(prefix + "/state"),
+		"lock_address":     knownvalue.StringExact(prefix + "/lock"),
+		"unlock_address":   knownvalue.StringExact(prefix + "/unlock"),
+		"lock_method":      knownvalue.StringExact("POST"),
+		"unlock_method":    knownvalue.StringExact("POST"),
+	})
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Ephemeral resources are supported since Terraform 1.10
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck: func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"lynx": providerserver.NewProtocol6WithError(New("test")()),
+			"echo": echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			// Lookup by identifiers
+			{
+				Config: testAccProviderConfig(server) + testAccEnvironmentResourceConfig("Development") + `
+ephemeral "lynx_environment_credentials" "test" {
+  project_id     = lynx_project.test.id
+  environment_id = lynx_environment.test.id
+}
+` + testAccEchoConfig(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data"), expected),
+				},
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccEnvironmentResourceConfig("Development") + `
+ephemeral "lynx_environment_credentials" "test" {
+  project_slug     = lynx_project.test.slug
+  environment_slug = "prod"
+}
+` + testAccEchoConfig(),
+				ExpectError: regexp.MustCompile("expected one environment with slug"),
+			},
+			// Lookup by slugs
+			{
+				Config: testAccProviderConfig(server) + testAccEnvironmentResourceConfig("Development") + `
+ephemeral "lynx_environment_credentials" "test" {
+  project_slug     = lynx_project.test.slug
+  environment_slug = lynx_environment.test.slug
+}
+` + testAccEchoConfig(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data"), expected),
+				},
+			},
+		},
+	})
+}
+
+// testAccEchoConfig returns the echo provider configuration exposing the
+// ephemeral credentials.
+func testAccEchoConfig() string {
+	return `
+provider "echo" {
+  data = ephemeral.lynx_environment_credentials.test
+}
+
+resource "echo" "test" {}
+`
+}
@@ -7,9 +7,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/clivern/terraform-provider-lynx/sdk"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -29,7 +31,7 @@ func NewEnvironmentResource() resource.Resource {
 
 // EnvironmentResource defines the resource implementation.
 type EnvironmentResource struct {
-	client *sdk.Client
+	client sdk.LynxAPI
 }
 
 // EnvironmentResourceModel describes the resource data model.
@@ -40,6 +42,7 @@ type EnvironmentResourceModel struct {
 	Username types.String               `tfsdk:"username"`
 	Secret   types.String               `tfsdk:"secret"`
 	Project  *ProjectResourceSmallModel `tfsdk:"project"`
+	Timeouts timeouts.Value             `tfsdk:"timeouts"`
 }
 
 // ProjectResourceSmallModel describes the nested project resource data model.
@@ -91,6 +94,14 @@ func (r *EnvironmentResource) Schema(ctx context.Context, req resource.SchemaReq
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -100,12 +111,12 @@ func (r *EnvironmentResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.Client)
+	client, ok := req.ProviderData.(sdk.LynxAPI)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected sdk.LynxAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -124,6 +135,17 @@ func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	newEnvironment := sdk.Environment{
 		Name:     data.Name.ValueString(),
 		Slug:     data.Slug.ValueString(),
@@ -136,7 +158,7 @@ func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateReq
 
 	tflog.Info(ctx, fmt.Sprintf("Create an environment with name %s", newEnvironment.Name))
 
-	createdEnvironment, err := r.client.CreateEnvironment(newEnvironment)
+	createdEnvironment, err := r.client.CreateEnvironment(ctx, newEnvironment)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -173,10 +195,28 @@ func (r *EnvironmentResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, fmt.Sprintf("Read an environment with id %s", data.ID.ValueString()))
 
 	// Retrieve the environment using the GetEnvironment method
-	environment, err := r.client.GetEnvironment(data.Project.ID.ValueString(), data.ID.ValueString())
+	environment, err := r.client.GetEnvironment(ctx, data.Project.ID.ValueString(), data.ID.ValueString())
+
+	if sdk.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Environment with id %s not found, removing it from state", data.ID.ValueString()))
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -210,6 +250,17 @@ func (r *EnvironmentResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Update the environment using the UpdateEnvironment method
 	updatedEnvironment := sdk.Environment{
 		ID:       data.ID.ValueString(),
@@ -224,7 +275,7 @@ func (r *EnvironmentResource) Update(ctx context.Context, req resource.UpdateReq
 
 	tflog.Info(ctx, fmt.Sprintf("Update an environment with id %s", updatedEnvironment.ID))
 
-	_, err := r.client.UpdateEnvironment(updatedEnvironment)
+	_, err := r.client.UpdateEnvironment(ctx, updatedEnvironment)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -250,11 +301,22 @@ func (r *EnvironmentResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, fmt.Sprintf("Delete an environment with id %s", data.ID.ValueString()))
 
-	err := r.client.DeleteEnvironment(data.Project.ID.ValueString(), data.ID.ValueString())
+	err := r.client.DeleteEnvironment(ctx, data.Project.ID.ValueString(), data.ID.ValueString())
 
-	if err != nil {
+	if err != nil && !sdk.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete environment, got error: %s", err.Error()),
@@ -266,5 +328,18 @@ func (r *EnvironmentResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *EnvironmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Environments are nested under projects, so the import ID holds both
+	// identifiers as <project_id>/<environment_id>
+	parts := strings.Split(req.ID, "/")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <project_id>/<environment_id>, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project").AtName("id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
 }
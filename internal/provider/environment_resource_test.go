@@ -0,0 +1,170 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxmock"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxtest"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccEnvironmentResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEnvironmentDestroy(server),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccEnvironmentResourceConfig("Development"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("lynx_environment.test", "id"),
+					resource.TestCheckResourceAttr("lynx_environment.test", "name", "Development"),
+					resource.TestCheckResourceAttr("lynx_environment.test", "slug", "dev"),
+					resource.TestCheckResourceAttr("lynx_environment.test", "username", "admin"),
+					resource.TestCheckResourceAttrPair("lynx_environment.test", "project.id", "lynx_project.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "lynx_environment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["lynx_environment.test"]
+
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["project.id"], rs.Primary.ID), nil
+				},
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccEnvironmentResourceConfig("Dev"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lynx_environment.test", "name", "Dev"),
+				),
+			},
+			// Drift detection testing
+			{
+				PreConfig: func() {
+					environment := testAccFindEnvironment(t, server, "dev")
+					environment.Secret = "changed-outside"
+
+					if _, err := server.Client().UpdateEnvironment(context.Background(), environment); err != nil {
+						t.Fatalf("unable to update environment: %s", err)
+					}
+				},
+				Config:             testAccProviderConfig(server) + testAccEnvironmentResourceConfig("Dev"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Deleted outside of Terraform testing
+			{
+				PreConfig: func() {
+					environment := testAccFindEnvironment(t, server, "dev")
+
+					if err := server.Client().DeleteEnvironment(context.Background(), environment.Project.ID, environment.ID); err != nil {
+						t.Fatalf("unable to delete environment: %s", err)
+					}
+				},
+				Config:             testAccProviderConfig(server) + testAccEnvironmentResourceConfig("Dev"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestUnitEnvironmentResourceDeleteNotFound(t *testing.T) {
+	client := &lynxmock.Client{
+		DeleteEnvironmentFunc: func(ctx context.Context, projectId, environmentId string) error {
+			return &sdk.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+
+	r, empty := testUnitResource(t, NewEnvironmentResource, client)
+
+	state := testUnitState(t, empty, EnvironmentResourceModel{
+		ID:       types.StringValue("environment-1"),
+		Name:     types.StringValue("Development"),
+		Slug:     types.StringValue("dev"),
+		Username: types.StringValue("admin"),
+		Secret:   types.StringValue("secret"),
+		Project:  &ProjectResourceSmallModel{ID: types.StringValue("project-1")},
+		Timeouts: testUnitTimeouts(),
+	})
+
+	resp := &fwresource.DeleteResponse{State: state}
+
+	r.Delete(context.Background(), fwresource.DeleteRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected an already deleted environment to be ignored, got: %v", resp.Diagnostics)
+	}
+
+	calls := client.CallsOf("DeleteEnvironment")
+
+	if len(calls) != 1 || calls[0].Args[0] != "project-1" || calls[0].Args[1] != "environment-1" {
+		t.Errorf("expected DeleteEnvironment to be called with the project and environment, got: %v", calls)
+	}
+}
+
+func testAccEnvironmentResourceConfig(name string) string {
+	return testAccProjectResourceConfig("Grafana") + fmt.Sprintf(`
+resource "lynx_environment" "test" {
+  name     = %[1]q
+  slug     = "dev"
+  username = "admin"
+  secret   = "~secret-here~"
+
+  project = {
+    id = lynx_project.test.id
+  }
+}
+`, name)
+}
+
+// testAccFindEnvironment finds an environment of the grafana project on the fake Lynx API by slug.
+func testAccFindEnvironment(t *testing.T, server *lynxtest.Server, slug string) sdk.Environment {
+	project := testAccFindProject(t, server, "grafana")
+
+	environments, err := sdk.IterateEnvironments(server.Client(), project.ID, sdk.ListOptions{Slug: slug}).All(context.Background())
+
+	if err != nil || len(environments) != 1 {
+		t.Fatalf("unable to find environment %s: %v", slug, err)
+	}
+
+	return environments[0]
+}
+
+func testAccCheckEnvironmentDestroy(server *lynxtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "lynx_environment" {
+				continue
+			}
+
+			_, err := server.Client().GetEnvironment(context.Background(), rs.Primary.Attributes["project.id"], rs.Primary.ID)
+
+			if !sdk.IsNotFound(err) {
+				return fmt.Errorf("environment %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/clivern/terraform-provider-lynx/sdk"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -29,7 +30,7 @@ func NewProjectResource() resource.Resource {
 
 // ProjectResource defines the resource implementation.
 type ProjectResource struct {
-	client *sdk.Client
+	client sdk.LynxAPI
 }
 
 // ProjectResourceModel describes the resource data model.
@@ -39,6 +40,7 @@ type ProjectResourceModel struct {
 	Slug        types.String            `tfsdk:"slug"`
 	Description types.String            `tfsdk:"description"`
 	Team        *TeamResourceSmallModel `tfsdk:"team"`
+	Timeouts    timeouts.Value          `tfsdk:"timeouts"`
 }
 
 // TeamResourceSmallModel describes the nested team resource data model.
@@ -84,6 +86,14 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -93,12 +103,12 @@ func (r *ProjectResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.Client)
+	client, ok := req.ProviderData.(sdk.LynxAPI)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected sdk.LynxAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -117,6 +127,17 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	newProject := sdk.Project{
 		Name:        data.Name.ValueString(),
 		Slug:        data.Slug.ValueString(),
@@ -126,7 +147,7 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 		},
 	}
 
-	createdProject, err := r.client.CreateProject(newProject)
+	createdProject, err := r.client.CreateProject(ctx, newProject)
 
 	tflog.Info(ctx, fmt.Sprintf("Read a project with name %s", newProject.Name))
 
@@ -161,10 +182,28 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, fmt.Sprintf("Read a project with id %s", data.ID.ValueString()))
 
 	// Retrieve the project using the GetProject method
-	project, err := r.client.GetProject(data.ID.ValueString())
+	project, err := r.client.GetProject(ctx, data.ID.ValueString())
+
+	if sdk.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Project with id %s not found, removing it from state", data.ID.ValueString()))
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -198,6 +237,17 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Update the project using the UpdateProject method
 	updatedProject := sdk.Project{
 		ID:          data.ID.ValueString(),
@@ -211,7 +261,7 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	tflog.Info(ctx, fmt.Sprintf("Update a project with id %s", updatedProject.ID))
 
-	_, err := r.client.UpdateProject(updatedProject)
+	_, err := r.client.UpdateProject(ctx, updatedProject)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -237,11 +287,22 @@ func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, fmt.Sprintf("Delete a project with id %s", data.ID.ValueString()))
 
-	err := r.client.DeleteProject(data.ID.ValueString())
+	err := r.client.DeleteProject(ctx, data.ID.ValueString())
 
-	if err != nil {
+	if err != nil && !sdk.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete project, got error: %s", err.Error()),
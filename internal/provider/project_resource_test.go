@@ -0,0 +1,135 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxtest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccProjectResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectDestroy(server),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccProjectResourceConfig("Grafana"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("lynx_project.test", "id"),
+					resource.TestCheckResourceAttr("lynx_project.test", "name", "Grafana"),
+					resource.TestCheckResourceAttr("lynx_project.test", "slug", "grafana"),
+					resource.TestCheckResourceAttrPair("lynx_project.test", "team.id", "lynx_team.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "lynx_project.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccProjectResourceConfig("Grafana Dashboards"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lynx_project.test", "name", "Grafana Dashboards"),
+				),
+			},
+			// Drift detection testing
+			{
+				PreConfig: func() {
+					project := testAccFindProject(t, server, "grafana")
+					project.Description = "Changed Outside"
+
+					if _, err := server.Client().UpdateProject(context.Background(), project); err != nil {
+						t.Fatalf("unable to update project: %s", err)
+					}
+				},
+				Config:             testAccProviderConfig(server) + testAccProjectResourceConfig("Grafana Dashboards"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Deleted outside of Terraform testing
+			{
+				PreConfig: func() {
+					project := testAccFindProject(t, server, "grafana")
+
+					if err := server.Client().DeleteProject(context.Background(), project.ID); err != nil {
+						t.Fatalf("unable to delete project: %s", err)
+					}
+				},
+				Config:             testAccProviderConfig(server) + testAccProjectResourceConfig("Grafana Dashboards"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccTeamConfig returns a team to attach projects and snapshots to.
+func testAccTeamConfig() string {
+	return `
+resource "lynx_team" "test" {
+  name        = "Monitoring"
+  slug        = "monitoring"
+  description = "System Monitoring Team"
+  members     = []
+}
+`
+}
+
+func testAccProjectResourceConfig(name string) string {
+	return testAccTeamConfig() + fmt.Sprintf(`
+resource "lynx_project" "test" {
+  name        = %[1]q
+  slug        = "grafana"
+  description = "Grafana Project"
+
+  team = {
+    id = lynx_team.test.id
+  }
+}
+`, name)
+}
+
+// testAccFindProject finds a project on the fake Lynx API by slug.
+func testAccFindProject(t *testing.T, server *lynxtest.Server, slug string) sdk.Project {
+	projects, err := sdk.IterateProjects(server.Client(), sdk.ListOptions{Slug: slug}).All(context.Background())
+
+	if err != nil || len(projects) != 1 {
+		t.Fatalf("unable to find project %s: %v", slug, err)
+	}
+
+	return projects[0]
+}
+
+func testAccCheckProjectDestroy(server *lynxtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "lynx_project" {
+				continue
+			}
+
+			_, err := server.Client().GetProject(context.Background(), rs.Primary.ID)
+
+			if !sdk.IsNotFound(err) {
+				return fmt.Errorf("project %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
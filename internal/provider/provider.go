@@ -6,22 +6,40 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/clivern/terraform-provider-lynx/sdk"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Default timeouts of the resources operations, they can be changed
+// with the timeouts block of each resource.
+const (
+	defaultCreateTimeout = 5 * time.Minute
+	defaultReadTimeout   = 2 * time.Minute
+	defaultUpdateTimeout = 5 * time.Minute
+	defaultDeleteTimeout = 5 * time.Minute
 )
 
 // Ensure lynxProvider satisfies various provider interfaces.
 var _ provider.Provider = &lynxProvider{}
 var _ provider.ProviderWithFunctions = &lynxProvider{}
+var _ provider.ProviderWithEphemeralResources = &lynxProvider{}
 
 // lynxProvider defines the provider implementation.
 type lynxProvider struct {
@@ -33,8 +51,32 @@ type lynxProvider struct {
 
 // LynxProviderModel describes the provider data model.
 type LynxProviderModel struct {
-	ApiURL types.String `tfsdk:"api_url"`
-	ApiKey types.String `tfsdk:"api_key"`
+	Profile    types.String `tfsdk:"profile"`
+	ConfigFile types.String `tfsdk:"config_file"`
+
+	ApiURL   types.String `tfsdk:"api_url"`
+	ApiKey   types.String `tfsdk:"api_key"`
+	Email    types.String `tfsdk:"email"`
+	Password types.String `tfsdk:"password"`
+
+	CredentialCommand types.String `tfsdk:"credential_command"`
+
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.String `tfsdk:"retry_wait_max"`
+
+	RequestTimeout types.String `tfsdk:"request_timeout"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	ProxyURL types.String `tfsdk:"proxy_url"`
+	Headers  types.Map    `tfsdk:"headers"`
+
+	SkipCredentialsValidation types.Bool `tfsdk:"skip_credentials_validation"`
 }
 
 func (p *lynxProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -45,6 +87,16 @@ func (p *lynxProvider) Metadata(ctx context.Context, req provider.MetadataReques
 func (p *lynxProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of the profile to load from the credentials file. Defaults to `default`. " +
+					"The provider attributes take precedence over the profile values, which take precedence over the environment variables " +
+					"like `LYNX_API_URL` and `LYNX_API_KEY`, which never complete the `api_url` and `api_key` of this profile. A profile selected with `LYNX_PROFILE` or the default profile comes after the environment variables",
+				Optional: true,
+			},
+			"config_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the credentials file holding the profiles. Defaults to `~/.lynx/credentials`",
+				Optional:            true,
+			},
 			"api_url": schema.StringAttribute{
 				MarkdownDescription: "Lynx API URL",
 				Optional:            true,
@@ -54,6 +106,69 @@ func (p *lynxProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email of a Lynx user to login with instead of an API key",
+				Optional:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password of the Lynx user to login with instead of an API key",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"credential_command": schema.StringAttribute{
+				MarkdownDescription: "Command that prints the API key as JSON like `{\"api_key\": \"...\", \"expires_at\": \"2024-01-01T00:00:00Z\"}`. " +
+					"It is used when no API key is set and runs again when the key expires or is rejected",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of retries for transient failures like connection resets, 429, 502, 503 and 504. Defaults to `3`, `0` disables retries",
+				Optional:            true,
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: "Minimum wait between retries as a duration like `1s`, it must be greater than zero. Defaults to `1s`",
+				Optional:            true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: "Maximum wait between retries as a duration like `30s`. Defaults to `30s`",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout of a single HTTP request as a duration like `30s`. Defaults to `10s`",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded CA bundle used to verify the Lynx server certificate",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA bundle used to verify the Lynx server certificate",
+				Optional:            true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded client certificate for mutual TLS",
+				Optional:            true,
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the PEM encoded private key of the client certificate",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip the verification of the Lynx server certificate. Only use it for testing",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "Proxy URL used to reach Lynx. Defaults to the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "Additional headers sent with every request. `X-API-Key` and `Content-Type` can't be overridden",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				MarkdownDescription: "Skip the check of the API URL and the credentials against the Lynx server when the provider is configured. Useful for offline plans",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -85,42 +200,613 @@ func (p *lynxProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		)
 	}
 
+	if data.Profile.IsUnknown() || data.ConfigFile.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Lynx Profile",
+			"The provider cannot load the Lynx profile as there is an unknown configuration value for the profile or the config file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LYNX_PROFILE and LYNX_CONFIG_FILE environment variables.",
+		)
+	}
+
+	if data.CredentialCommand.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credential_command"),
+			"Unknown Lynx Credential Command",
+			"The provider cannot fetch the Lynx API Key as there is an unknown configuration value for the credential command. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LYNX_CREDENTIAL_COMMAND environment variable.",
+		)
+	}
+
+	if data.Email.IsUnknown() || data.Password.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Lynx Login Credentials",
+			"The provider cannot login to Lynx as there is an unknown configuration value for the email or the password. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LYNX_EMAIL and LYNX_PASSWORD environment variables.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	api_url := os.Getenv("LYNX_API_URL")
-	api_key := os.Getenv("LYNX_API_KEY")
+	profile := p.loadProfile(ctx, data, resp)
 
-	if !data.ApiURL.IsNull() {
-		api_url = data.ApiURL.ValueString()
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if !data.ApiKey.IsNull() {
+	api_url := stringValue(data.ApiURL, "LYNX_API_URL")
+	api_key := stringValue(data.ApiKey, "LYNX_API_KEY")
+
+	if !data.Profile.IsNull() {
+		// A profile selected in the configuration provides the URL and the
+		// key together, mixing one of them with an environment variable
+		// would send the key of an instance to another one
+		api_url = data.ApiURL.ValueString()
 		api_key = data.ApiKey.ValueString()
+
+		if api_url == "" {
+			api_url = profile.APIURL
+		}
+
+		if api_key == "" {
+			api_key = profile.APIKey
+		}
+	} else {
+		if useProfile(data, data.ApiURL.ValueString() != "", api_url != "", profile.APIURL != "") {
+			api_url = profile.APIURL
+		}
+
+		if useProfile(data, data.ApiKey.ValueString() != "", api_key != "", profile.APIKey != "") {
+			api_key = profile.APIKey
+		}
 	}
 
+	credential_command := stringValue(data.CredentialCommand, "LYNX_CREDENTIAL_COMMAND")
+
 	client := sdk.NewClient(api_url, api_key)
+
+	max_retries := os.Getenv("LYNX_MAX_RETRIES")
+	retry_wait_min := os.Getenv("LYNX_RETRY_WAIT_MIN")
+	retry_wait_max := os.Getenv("LYNX_RETRY_WAIT_MAX")
+
+	if max_retries != "" {
+		value, err := strconv.Atoi(max_retries)
+
+		if err != nil || value < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Lynx Max Retries",
+				fmt.Sprintf("The LYNX_MAX_RETRIES environment variable must be a non negative integer, got: %s", max_retries),
+			)
+		}
+
+		client.MaxRetries = value
+	}
+
+	if !data.MaxRetries.IsNull() {
+		if data.MaxRetries.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Lynx Max Retries",
+				fmt.Sprintf("The max_retries attribute must be a non negative integer, got: %d", data.MaxRetries.ValueInt64()),
+			)
+		}
+
+		client.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
+	if !data.RetryWaitMin.IsNull() {
+		retry_wait_min = data.RetryWaitMin.ValueString()
+	}
+
+	if !data.RetryWaitMax.IsNull() {
+		retry_wait_max = data.RetryWaitMax.ValueString()
+	}
+
+	if retry_wait_min != "" {
+		client.RetryWaitMin = parseDuration(path.Root("retry_wait_min"), retry_wait_min, resp)
+
+		// The backoff doubles the minimum wait, so zero would never wait
+		if client.RetryWaitMin == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_wait_min"),
+				"Invalid Lynx Retry Wait",
+				fmt.Sprintf("The retry_wait_min must be greater than zero, got: %s", retry_wait_min),
+			)
+		}
+	}
+
+	if retry_wait_max != "" {
+		client.RetryWaitMax = parseDuration(path.Root("retry_wait_max"), retry_wait_max, resp)
+	}
+
+	request_timeout := stringValue(data.RequestTimeout, "LYNX_REQUEST_TIMEOUT")
+
+	if request_timeout != "" {
+		client.HTTPClient.Timeout = parseDuration(path.Root("request_timeout"), request_timeout, resp)
+	}
+
+	if client.RetryWaitMin > client.RetryWaitMax {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid Lynx Retry Wait",
+			fmt.Sprintf("The retry_wait_min (%s) must not be greater than retry_wait_max (%s).", client.RetryWaitMin, client.RetryWaitMax),
+		)
+	}
+
+	p.configureTLS(data, profile, client, resp)
+	p.configureProxy(ctx, data, client, resp)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	has_credentials := api_key != "" || credential_command != "" || stringValue(data.Email, "LYNX_EMAIL") != "" || stringValue(data.Password, "LYNX_PASSWORD") != ""
+
+	if !data.Profile.IsNull() && (api_url == "" || !has_credentials) {
+		missing := "api_url"
+
+		if api_url != "" {
+			missing = "api_key"
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Incomplete Lynx Profile",
+			fmt.Sprintf("The profile %s has no %s. Set it in the profile or in the provider block, "+
+				"the LYNX_API_URL and LYNX_API_KEY environment variables are ignored when the profile attribute is set.", profile.Name, missing),
+		)
+
+		return
+	}
+
+	if api_url == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_url"),
+			"Missing Lynx API URL",
+			"The provider cannot create the Lynx API client as there is a missing or empty value for the Lynx API URL. "+
+				"Set the api_url value in the configuration or use the LYNX_API_URL environment variable.",
+		)
+	}
+
+	if !has_credentials {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key"),
+			"Missing Lynx API Key",
+			"The provider cannot create the Lynx API client as there is a missing or empty value for the Lynx API Key. "+
+				"Set the api_key value in the configuration or use the LYNX_API_KEY environment variable, "+
+				"fetch it with the credential_command attribute, or login with the email and password attributes.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	skip_credentials_validation := boolValue(
+		path.Root("skip_credentials_validation"),
+		data.SkipCredentialsValidation,
+		"LYNX_SKIP_CREDENTIALS_VALIDATION",
+		resp,
+	)
+
+	// The attribute blamed when the API rejects the credentials
+	credentials_path := path.Root("api_key")
+
+	if api_key == "" && credential_command != "" {
+		credentials_path = path.Root("credential_command")
+
+		p.configureCommand(ctx, credential_command, client, !skip_credentials_validation, resp)
+	} else if api_key == "" {
+		credentials_path = path.Root("email")
+
+		p.configureLogin(ctx, data, client, !skip_credentials_validation, resp)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if skip_credentials_validation {
+		tflog.Info(ctx, "Skipping the validation of the Lynx credentials")
+	} else {
+		p.validateCredentials(ctx, credentials_path, client, resp)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
 }
 
 func (p *lynxProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewUserResource,
 		NewTeamResource,
+		NewTeamMemberResource,
 		NewSnapshotResource,
+		NewSnapshotRestoreResource,
+		NewSnapshotExportResource,
 		NewProjectResource,
 		NewEnvironmentResource,
 	}
 }
 
+func (p *lynxProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewEnvironmentCredentialsEphemeralResource,
+	}
+}
+
 func (p *lynxProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{}
 }
 
 func (p *lynxProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewBackendConfigFunction,
+		NewSlugifyFunction,
+	}
+}
+
+// loadProfile loads the selected profile from the credentials file. The
+// default profile is optional, so an empty profile is returned when it
+// doesn't exist
+func (p *lynxProvider) loadProfile(ctx context.Context, data LynxProviderModel, resp *provider.ConfigureResponse) *sdk.Profile {
+	name := stringValue(data.Profile, "LYNX_PROFILE")
+	file := stringValue(data.ConfigFile, "LYNX_CONFIG_FILE")
+
+	explicit := name != "" || file != ""
+
+	if name == "" {
+		name = sdk.DefaultProfile
+	}
+
+	if file == "" {
+		value, err := sdk.DefaultConfigFile()
+
+		if err != nil {
+			return &sdk.Profile{}
+		}
+
+		file = value
+	}
+
+	if _, err := os.Stat(file); err != nil && !explicit {
+		return &sdk.Profile{}
+	}
+
+	profile, err := sdk.LoadProfile(file, name)
+
+	if errors.Is(err, sdk.ErrProfileNotFound) {
+		if stringValue(data.Profile, "LYNX_PROFILE") == "" {
+			return &sdk.Profile{}
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Missing Lynx Profile",
+			fmt.Sprintf("The provider cannot find the profile: %s", err.Error()),
+		)
+
+		return &sdk.Profile{}
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("config_file"),
+			"Invalid Lynx Credentials File",
+			fmt.Sprintf("The provider cannot load the credentials file: %s", err.Error()),
+		)
+
+		return &sdk.Profile{}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Loaded Lynx profile %s from %s", profile.Name, file))
+
+	return profile
+}
+
+// configureTLS sets the client TLS configuration from the provider
+// attributes or their environment variables
+func (p *lynxProvider) configureTLS(data LynxProviderModel, profile *sdk.Profile, client *sdk.Client, resp *provider.ConfigureResponse) {
+	ca_cert_file := stringValue(data.CACertFile, "LYNX_CA_CERT_FILE")
+	ca_cert_pem := stringValue(data.CACertPEM, "LYNX_CA_CERT_PEM")
+	client_cert_file := stringValue(data.ClientCertFile, "LYNX_CLIENT_CERT_FILE")
+	client_key_file := stringValue(data.ClientKeyFile, "LYNX_CLIENT_KEY_FILE")
+	insecure_skip_verify := profile.InsecureSkipVerify
+
+	if useProfile(
+		data,
+		data.CACertFile.ValueString() != "" || data.CACertPEM.ValueString() != "",
+		ca_cert_file != "" || ca_cert_pem != "",
+		profile.CACertFile != "",
+	) {
+		ca_cert_file = profile.CACertFile
+		ca_cert_pem = ""
+	}
+
+	if useProfile(
+		data,
+		data.ClientCertFile.ValueString() != "" || data.ClientKeyFile.ValueString() != "",
+		client_cert_file != "" || client_key_file != "",
+		profile.ClientCertFile != "" || profile.ClientKeyFile != "",
+	) {
+		client_cert_file = profile.ClientCertFile
+		client_key_file = profile.ClientKeyFile
+	}
+
+	insecure_env := os.Getenv("LYNX_INSECURE_SKIP_VERIFY") != ""
+
+	if !useProfile(data, !data.InsecureSkipVerify.IsNull(), insecure_env, profile.InsecureSkipVerify) && (!data.InsecureSkipVerify.IsNull() || insecure_env) {
+		insecure_skip_verify = boolValue(path.Root("insecure_skip_verify"), data.InsecureSkipVerify, "LYNX_INSECURE_SKIP_VERIFY", resp)
+	}
+
+	if ca_cert_file == "" && ca_cert_pem == "" && client_cert_file == "" && client_key_file == "" && !insecure_skip_verify {
+		return
+	}
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecure_skip_verify,
+	}
+
+	if ca_cert_file != "" || ca_cert_pem != "" {
+		pool, err := sdk.LoadCACertPool(ca_cert_file, ca_cert_pem)
+
+		if err != nil {
+			attr := path.Root("ca_cert_pem")
+
+			if ca_cert_file != "" {
+				attr = path.Root("ca_cert_file")
+			}
+
+			resp.Diagnostics.AddAttributeError(
+				attr,
+				"Invalid Lynx CA Certificate",
+				fmt.Sprintf("The provider cannot load the CA certificate: %s", err.Error()),
+			)
+		}
+
+		config.RootCAs = pool
+	}
+
+	if client_cert_file != "" || client_key_file != "" {
+		cert, err := sdk.LoadClientCertificate(client_cert_file, client_key_file)
+
+		if err != nil {
+			attr := path.Root("client_cert_file")
+
+			if client_key_file == "" {
+				attr = path.Root("client_key_file")
+			}
+
+			resp.Diagnostics.AddAttributeError(
+				attr,
+				"Invalid Lynx Client Certificate",
+				fmt.Sprintf("The provider cannot load the client certificate: %s", err.Error()),
+			)
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if insecure_skip_verify {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"Insecure Lynx Connection",
+			"The Lynx server certificate will not be verified. Do not use insecure_skip_verify in production.",
+		)
+	}
+
+	client.SetTLSConfig(config)
+}
+
+// configureProxy sets the client proxy and the additional request headers
+func (p *lynxProvider) configureProxy(ctx context.Context, data LynxProviderModel, client *sdk.Client, resp *provider.ConfigureResponse) {
+	proxy_url := stringValue(data.ProxyURL, "LYNX_PROXY_URL")
+
+	if proxy_url != "" {
+		value, err := url.Parse(proxy_url)
+
+		if err != nil || value.Scheme == "" || value.Host == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Lynx Proxy URL",
+				fmt.Sprintf("The proxy URL must be an absolute URL like http://proxy.example.com:3128, got: %s", proxy_url),
+			)
+		} else {
+			client.SetProxy(value)
+		}
+	}
+
+	if data.Headers.IsNull() || data.Headers.IsUnknown() {
+		return
+	}
+
+	headers := map[string]string{}
+
+	resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &headers, false)...)
+
+	for name := range headers {
+		if sdk.IsReservedHeader(name) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("headers").AtMapKey(name),
+				"Reserved Lynx Header",
+				fmt.Sprintf("The %s header is managed by the provider and can't be overridden.", name),
+			)
+		}
+	}
+
+	client.Headers = headers
+}
+
+// configureLogin exchanges the user's email and password for a session
+// token that is shared by all the resources. Without login, the session
+// is created on the first request instead
+func (p *lynxProvider) configureLogin(ctx context.Context, data LynxProviderModel, client *sdk.Client, login bool, resp *provider.ConfigureResponse) {
+	email := stringValue(data.Email, "LYNX_EMAIL")
+	password := stringValue(data.Password, "LYNX_PASSWORD")
+
+	if email == "" && password == "" {
+		return
+	}
+
+	if email == "" || password == "" {
+		attr := path.Root("email")
+
+		if password == "" {
+			attr = path.Root("password")
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			attr,
+			"Missing Lynx Login Credentials",
+			"The provider needs both the email and the password to login. "+
+				"Set both attributes or the LYNX_EMAIL and LYNX_PASSWORD environment variables.",
+		)
+
+		return
+	}
+
+	credentials := sdk.NewPasswordCredentials(client, email, password)
+
+	if !login {
+		client.Credentials = credentials
+		return
+	}
+
+	if _, err := credentials.APIKey(ctx); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("email"),
+			"Unable to Login to Lynx",
+			fmt.Sprintf("The provider cannot login to Lynx: %s", err.Error()),
+		)
+
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Logged in to Lynx with email %s", email))
+
+	client.Credentials = credentials
+}
+
+// configureCommand fetches the API key with an external command like a
+// secrets manager helper. Without run, the command runs on the first
+// request instead
+func (p *lynxProvider) configureCommand(ctx context.Context, command string, client *sdk.Client, run bool, resp *provider.ConfigureResponse) {
+	credentials := sdk.NewCommandCredentials(command)
+
+	if run {
+		if _, err := credentials.APIKey(ctx); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credential_command"),
+				"Unable to Fetch Lynx API Key",
+				fmt.Sprintf("The provider cannot fetch the API key with the credential command: %s", err.Error()),
+			)
+
+			return
+		}
+
+		tflog.Info(ctx, "Fetched the Lynx API key with the credential command")
+	}
+
+	client.Credentials = credentials
+}
+
+// validateCredentials checks that the Lynx API is reachable and accepts
+// the credentials, so a misconfiguration fails early instead of on the
+// first resource
+func (p *lynxProvider) validateCredentials(ctx context.Context, attr path.Path, client *sdk.Client, resp *provider.ConfigureResponse) {
+	info, err := client.GetServerInfo(ctx)
+
+	if sdk.IsUnauthorized(err) {
+		resp.Diagnostics.AddAttributeError(
+			attr,
+			"Invalid Lynx Credentials",
+			fmt.Sprintf("The Lynx API rejected the provider credentials: %s", err.Error()),
+		)
+
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_url"),
+			"Unable to Reach Lynx API",
+			fmt.Sprintf("The provider cannot reach the Lynx API at %s: %s. "+
+				"Check the api_url value or set skip_credentials_validation to plan offline.", client.ApiURL, err.Error()),
+		)
+
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Connected to Lynx server version %s", info.Version))
+}
+
+// useProfile checks whether a setting takes the profile value. Values in
+// the configuration always win. A profile selected with the profile
+// attribute overrides the environment variables, which override the
+// profiles selected with LYNX_PROFILE or loaded by default
+func useProfile(data LynxProviderModel, configured bool, fromEnv bool, inProfile bool) bool {
+	if configured || !inProfile {
+		return false
+	}
+
+	return !data.Profile.IsNull() || !fromEnv
+}
+
+// stringValue returns the attribute value or the environment variable if the attribute is not set
+func stringValue(attr types.String, env string) string {
+	if !attr.IsNull() {
+		return attr.ValueString()
+	}
+
+	return os.Getenv(env)
+}
+
+// boolValue returns the attribute value or the environment variable if the attribute is not set
+func boolValue(attrPath path.Path, attr types.Bool, env string, resp *provider.ConfigureResponse) bool {
+	if !attr.IsNull() {
+		return attr.ValueBool()
+	}
+
+	value := os.Getenv(env)
+
+	if value == "" {
+		return false
+	}
+
+	result, err := strconv.ParseBool(value)
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			attrPath,
+			"Invalid Boolean Value",
+			fmt.Sprintf("The %s environment variable must be a boolean like true or false, got: %s", env, value),
+		)
+	}
+
+	return result
+}
+
+// parseDuration parses a duration attribute and reports a diagnostic if it is invalid
+func parseDuration(attr path.Path, value string, resp *provider.ConfigureResponse) time.Duration {
+	duration, err := time.ParseDuration(value)
+
+	if err != nil || duration < 0 {
+		resp.Diagnostics.AddAttributeError(
+			attr,
+			"Invalid Duration",
+			fmt.Sprintf("The %s value must be a positive duration like 1s or 500ms, got: %s", attr, value),
+		)
+	}
+
+	return duration
 }
 
 func New(version string) func() provider.Provider {
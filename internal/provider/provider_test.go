@@ -5,22 +5,652 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxtest"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
+// testAccAPIKey is the API key accepted by the fake Lynx API.
+const testAccAPIKey = "acceptance-test-key"
+
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
 // acceptance testing. The factory function will be invoked for every Terraform
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
+	"lynx": providerserver.NewProtocol6WithError(New("test")()),
 }
 
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	// Acceptance tests run against a local fake Lynx API, so there is no
+	// environment variable to check. Make sure a real instance is not used
+	// by mistake through the provider environment variables.
+	t.Setenv("LYNX_API_URL", "")
+	t.Setenv("LYNX_API_KEY", "")
+	t.Setenv("LYNX_PROFILE", "")
+	t.Setenv("LYNX_CONFIG_FILE", "")
+
+	// Ignore the credentials file of the user running the tests
+	t.Setenv("HOME", t.TempDir())
+}
+
+// testAccServer starts a fake Lynx API for the duration of a test.
+func testAccServer(t *testing.T) *lynxtest.Server {
+	server := lynxtest.NewServer(testAccAPIKey)
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// testAccProviderConfig returns the provider configuration pointing to the fake Lynx API.
+func testAccProviderConfig(server *lynxtest.Server) string {
+	return fmt.Sprintf(`
+provider "lynx" {
+  api_url        = %[1]q
+  api_key        = %[2]q
+  retry_wait_min = "10ms"
+  retry_wait_max = "50ms"
+}
+`, server.APIURL(), server.APIKey)
+}
+
+func TestAccProviderCredentialsValidation(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Missing API URL
+			{
+				Config: fmt.Sprintf(`
+provider "lynx" {
+  api_key = %[1]q
+}
+`, server.APIKey) + testAccUserResourceConfig("Stella", "regular"),
+				ExpectError: regexp.MustCompile("Missing Lynx API URL"),
+			},
+			// Invalid API key
+			{
+				Config: fmt.Sprintf(`
+provider "lynx" {
+  api_url = %[1]q
+  api_key = "invalid-key"
+}
+`, server.APIURL()) + testAccUserResourceConfig("Stella", "regular"),
+				ExpectError: regexp.MustCompile("Invalid Lynx Credentials"),
+			},
+			// Unreachable API
+			{
+				Config: `
+provider "lynx" {
+  api_url     = "http://127.0.0.1:1/api/v1"
+  api_key     = "key"
+  max_retries = 0
+}
+` + testAccUserResourceConfig("Stella", "regular"),
+				ExpectError: regexp.MustCompile("Unable to Reach Lynx API"),
+			},
+			// The validation is skipped so the error comes from the resource
+			{
+				Config: fmt.Sprintf(`
+provider "lynx" {
+  api_url                     = %[1]q
+  api_key                     = "invalid-key"
+  max_retries                 = 0
+  skip_credentials_validation = true
+}
+`, server.APIURL()) + testAccUserResourceConfig("Stella", "regular"),
+				ExpectError: regexp.MustCompile("Unable to create user"),
+			},
+		},
+	})
+
+	if count := server.CountRequests("GET", "/info"); count != 1 {
+		t.Errorf("expected the credentials to be validated once, got %d", count)
+	}
+}
+
+func TestAccProviderProfile(t *testing.T) {
+	server := testAccServer(t)
+	file := filepath.Join(t.TempDir(), "credentials")
+
+	err := os.WriteFile(file, []byte(fmt.Sprintf(`
+[default]
+api_url = http://127.0.0.1:1/api/v1
+api_key = invalid-key
+
+[test]
+api_url = %[1]s
+api_key = %[2]s
+
+[partial]
+api_url = %[1]s
+`, server.APIURL(), server.APIKey)), 0o600)
+
+	if err != nil {
+		t.Fatalf("unable to write credentials file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Missing profile
+			{
+				Config: fmt.Sprintf(`
+provider "lynx" {
+  config_file = %[1]q
+  profile     = "prod"
+}
+`, file) + testAccUserResourceConfig("Stella", "regular"),
+				ExpectError: regexp.MustCompile("Missing Lynx Profile"),
+			},
+			// The API URL and key come from the profile
+			{
+				Config: fmt.Sprintf(`
+provider "lynx" {
+  config_file = %[1]q
+  profile     = "test"
+}
+`, file) + testAccUserResourceConfig("Stella", "regular"),
+				Check: resource.TestCheckResourceAttr("lynx_user.test", "name", "Stella"),
+			},
+			// The configuration takes precedence over the profile
+			{
+				Config: fmt.Sprintf(`
+provider "lynx" {
+  config_file = %[1]q
+  api_url     = %[2]q
+  api_key     = %[3]q
+}
+`, file, server.APIURL(), server.APIKey) + testAccUserResourceConfig("Stella", "regular"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccProviderProfilePrecedence(t *testing.T) {
+	server := testAccServer(t)
+	file := filepath.Join(t.TempDir(), "credentials")
+
+	err := os.WriteFile(file, []byte(fmt.Sprintf(`
+[default]
+api_url = http://127.0.0.1:1/api/v1
+api_key = invalid-key
+
+[test]
+api_url = %[1]s
+api_key = %[2]s
+
+[partial]
+api_url = %[1]s
+`, server.APIURL(), server.APIKey)), 0o600)
+
+	if err != nil {
+		t.Fatalf("unable to write credentials file: %s", err)
+	}
+
+	config := fmt.Sprintf(`
+provider "lynx" {
+  config_file = %[1]q
+  max_retries = 0
+}
+`, file) + testAccUserResourceConfig("Stella", "regular")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The profile attribute takes precedence over the environment variables
+			{
+				PreConfig: func() {
+					t.Setenv("LYNX_API_URL", "http://127.0.0.1:1/api/v1")
+					t.Setenv("LYNX_API_KEY", "invalid-key")
+				},
+				Config: fmt.Sprintf(`
+provider "lynx" {
+  config_file = %[1]q
+  profile     = "test"
+  max_retries = 0
+}
+`, file) + testAccUserResourceConfig("Stella", "regular"),
+				Check: resource.TestCheckResourceAttr("lynx_user.test", "name", "Stella"),
+			},
+			// The environment variables take precedence over the default profile
+			{
+				PreConfig: func() {
+					t.Setenv("LYNX_API_URL", server.APIURL())
+					t.Setenv("LYNX_API_KEY", server.APIKey)
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			// And over a profile selected with LYNX_PROFILE
+			{
+				PreConfig: func() {
+					t.Setenv("LYNX_PROFILE", "test")
+					t.Setenv("LYNX_API_KEY", "invalid-key")
+				},
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Lynx Credentials"),
+			},
+			// A profile selected with the profile attribute is never completed
+			// with the environment variables, even with a valid key
+			{
+				PreConfig: func() {
+					t.Setenv("LYNX_API_KEY", server.APIKey)
+				},
+				Config: fmt.Sprintf(`
+provider "lynx" {
+  config_file = %[1]q
+  profile     = "partial"
+  max_retries = 0
+}
+`, file) + testAccUserResourceConfig("Stella", "regular"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Incomplete Lynx Profile"),
+			},
+			// Restore a working key for the destroy
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccProviderHeaders(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "lynx" {
+  api_url = %[1]q
+  api_key = %[2]q
+
+  headers = {
+    "X-Request-Source" = "terraform"
+  }
+}
+`, server.APIURL(), server.APIKey) + testAccUserResourceConfig("Stella", "regular"),
+				Check: func(s *terraform.State) error {
+					for _, req := range server.Requests() {
+						if req.Header.Get("X-Request-Source") != "terraform" {
+							return fmt.Errorf("expected the custom header on %s %s, got: %v", req.Method, req.Path, req.Header)
+						}
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccProviderCredentialCommand(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "lynx" {
+  api_url            = %[1]q
+  credential_command = "exit 1"
+}
+`, server.APIURL()) + testAccUserResourceConfig("Stella", "regular"),
+				ExpectError: regexp.MustCompile("Unable to Fetch Lynx API Key"),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "lynx" {
+  api_url            = %[1]q
+  credential_command = "echo '{\"api_key\": \"%[2]s\"}'"
+}
+`, server.APIURL(), server.APIKey) + testAccUserResourceConfig("Stella", "regular"),
+				Check: resource.TestCheckResourceAttr("lynx_user.test", "name", "Stella"),
+			},
+		},
+	})
+}
+
+func TestUnitProviderConfigureTLS(t *testing.T) {
+	// Ignore the environment and the credentials file of the user
+	testAccPreCheck(t)
+
+	dir := t.TempDir()
+	files := map[string][2]string{}
+
+	ca, err := lynxtest.NewCA()
+
+	if err != nil {
+		t.Fatalf("unable to generate CA: %s", err)
+	}
+
+	for _, name := range []string{"ca", "client", "other"} {
+		cert := ca
+
+		if name != "ca" {
+			cert, err = ca.Issue(name)
+
+			if err != nil {
+				t.Fatalf("unable to issue certificate: %s", err)
+			}
+		}
+
+		certFile, keyFile, err := cert.WriteFiles(dir, name)
+
+		if err != nil {
+			t.Fatalf("unable to write certificate: %s", err)
+		}
+
+		files[name] = [2]string{certFile, keyFile}
+	}
+
+	caFile := files["ca"][0]
+	certFile, keyFile := files["client"][0], files["client"][1]
+	otherKeyFile := files["other"][1]
+
+	for _, test := range []struct {
+		name     string
+		data     LynxProviderModel
+		severity diag.Severity
+		summary  string
+		path     path.Path
+	}{
+		{
+			name: "valid",
+			data: LynxProviderModel{CACertFile: types.StringValue(caFile), ClientCertFile: types.StringValue(certFile), ClientKeyFile: types.StringValue(keyFile)},
+		},
+		{
+			name:     "invalid CA content",
+			data:     LynxProviderModel{CACertPEM: types.StringValue("not a certificate")},
+			severity: diag.SeverityError,
+			summary:  "Invalid Lynx CA Certificate",
+			path:     path.Root("ca_cert_pem"),
+		},
+		{
+			name:     "unreadable CA file",
+			data:     LynxProviderModel{CACertFile: types.StringValue(dir)},
+			severity: diag.SeverityError,
+			summary:  "Invalid Lynx CA Certificate",
+			path:     path.Root("ca_cert_file"),
+		},
+		{
+			name:     "mismatched key",
+			data:     LynxProviderModel{ClientCertFile: types.StringValue(certFile), ClientKeyFile: types.StringValue(otherKeyFile)},
+			severity: diag.SeverityError,
+			summary:  "Invalid Lynx Client Certificate",
+			path:     path.Root("client_cert_file"),
+		},
+		{
+			name:     "certificate without key",
+			data:     LynxProviderModel{ClientCertFile: types.StringValue(certFile)},
+			severity: diag.SeverityError,
+			summary:  "Invalid Lynx Client Certificate",
+			path:     path.Root("client_key_file"),
+		},
+		{
+			name:     "insecure",
+			data:     LynxProviderModel{InsecureSkipVerify: types.BoolValue(true)},
+			severity: diag.SeverityWarning,
+			summary:  "Insecure Lynx Connection",
+			path:     path.Root("insecure_skip_verify"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.data.ApiURL = types.StringValue("https://lynx.example.com/api/v1")
+			test.data.ApiKey = types.StringValue(testAccAPIKey)
+			test.data.SkipCredentialsValidation = types.BoolValue(true)
+
+			diags := testUnitConfigure(t, test.data)
+
+			if test.summary == "" {
+				if len(diags) > 0 {
+					t.Errorf("expected no diagnostics, got: %v", diags)
+				}
+
+				return
+			}
+
+			if len(diags) != 1 {
+				t.Fatalf("expected a %s diagnostic, got: %v", test.summary, diags)
+			}
+
+			withPath, ok := diags[0].(diag.DiagnosticWithPath)
+
+			if diags[0].Severity() != test.severity || diags[0].Summary() != test.summary || !ok || !withPath.Path().Equal(test.path) {
+				t.Errorf("expected a %s diagnostic on %s, got: %v", test.summary, test.path, diags[0])
+			}
+		})
+	}
+}
+
+func TestUnitProviderConfigureHeaders(t *testing.T) {
+	testAccPreCheck(t)
+
+	for _, test := range []struct {
+		name     string
+		headers  map[string]string
+		proxyURL string
+		summary  string
+		path     path.Path
+	}{
+		{
+			name:     "valid",
+			headers:  map[string]string{"X-Request-Source": "terraform"},
+			proxyURL: "http://proxy.example.com:3128",
+		},
+		{
+			name:    "api key",
+			headers: map[string]string{"X-API-Key": "override"},
+			summary: "Reserved Lynx Header",
+			path:    path.Root("headers").AtMapKey("X-API-Key"),
+		},
+		{
+			name:    "lower case api key",
+			headers: map[string]string{"x-api-key": "override"},
+			summary: "Reserved Lynx Header",
+			path:    path.Root("headers").AtMapKey("x-api-key"),
+		},
+		{
+			name:    "content type",
+			headers: map[string]string{"CONTENT-TYPE": "text/plain"},
+			summary: "Reserved Lynx Header",
+			path:    path.Root("headers").AtMapKey("CONTENT-TYPE"),
+		},
+		{
+			name:     "proxy without scheme",
+			proxyURL: "proxy.example.com:3128",
+			summary:  "Invalid Lynx Proxy URL",
+			path:     path.Root("proxy_url"),
+		},
+		{
+			name:     "proxy without host",
+			proxyURL: "http://",
+			summary:  "Invalid Lynx Proxy URL",
+			path:     path.Root("proxy_url"),
+		},
+		{
+			name:     "unparsable proxy",
+			proxyURL: "http://proxy.example.com:port",
+			summary:  "Invalid Lynx Proxy URL",
+			path:     path.Root("proxy_url"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			headers, _ := types.MapValueFrom(context.Background(), types.StringType, test.headers)
+
+			data := LynxProviderModel{
+				ApiURL:                    types.StringValue("https://lynx.example.com/api/v1"),
+				ApiKey:                    types.StringValue(testAccAPIKey),
+				Headers:                   headers,
+				SkipCredentialsValidation: types.BoolValue(true),
+			}
+
+			if test.proxyURL != "" {
+				data.ProxyURL = types.StringValue(test.proxyURL)
+			}
+
+			diags := testUnitConfigure(t, data)
+
+			if test.summary == "" {
+				if len(diags) > 0 {
+					t.Errorf("expected no diagnostics, got: %v", diags)
+				}
+
+				return
+			}
+
+			if len(diags) != 1 {
+				t.Fatalf("expected a %s diagnostic, got: %v", test.summary, diags)
+			}
+
+			withPath, ok := diags[0].(diag.DiagnosticWithPath)
+
+			if diags[0].Severity() != diag.SeverityError || diags[0].Summary() != test.summary || !ok || !withPath.Path().Equal(test.path) {
+				t.Errorf("expected a %s error on %s, got: %v", test.summary, test.path, diags[0])
+			}
+		})
+	}
+}
+
+func TestUnitProviderConfigureRetryWait(t *testing.T) {
+	testAccPreCheck(t)
+
+	for _, value := range []string{"0s", "0"} {
+		diags := testUnitConfigure(t, LynxProviderModel{
+			ApiURL:                    types.StringValue("https://lynx.example.com/api/v1"),
+			ApiKey:                    types.StringValue(testAccAPIKey),
+			RetryWaitMin:              types.StringValue(value),
+			SkipCredentialsValidation: types.BoolValue(true),
+		})
+
+		if len(diags) != 1 || diags[0].Summary() != "Invalid Lynx Retry Wait" {
+			t.Errorf("expected a zero retry_wait_min %q to be rejected, got: %v", value, diags)
+		}
+	}
+
+	diags := testUnitConfigure(t, LynxProviderModel{
+		ApiURL:                    types.StringValue("https://lynx.example.com/api/v1"),
+		ApiKey:                    types.StringValue(testAccAPIKey),
+		RetryWaitMin:              types.StringValue("10ms"),
+		SkipCredentialsValidation: types.BoolValue(true),
+	})
+
+	if len(diags) > 0 {
+		t.Errorf("expected no diagnostics, got: %v", diags)
+	}
+}
+
+// testUnitConfigure configures the provider with the model and returns
+// the diagnostics.
+func testUnitConfigure(t *testing.T, data LynxProviderModel) diag.Diagnostics {
+	ctx := context.Background()
+	p := New("test")()
+
+	if data.Headers.ElementType(ctx) == nil {
+		data.Headers = types.MapNull(types.StringType)
+	}
+
+	schemaResp := &provider.SchemaResponse{}
+
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	config := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	if diags := config.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unable to build config: %v", diags)
+	}
+
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, resp)
+
+	return resp.Diagnostics
+}
+
+// testUnitResource returns a resource configured with the given client
+// and an empty state matching its schema.
+func testUnitResource(t *testing.T, newResource func() fwresource.Resource, client interface{}) (fwresource.Resource, tfsdk.State) {
+	ctx := context.Background()
+	r := newResource()
+
+	configureResp := &fwresource.ConfigureResponse{}
+
+	r.(fwresource.ResourceWithConfigure).Configure(ctx, fwresource.ConfigureRequest{ProviderData: client}, configureResp)
+
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unable to configure resource: %v", configureResp.Diagnostics)
+	}
+
+	schemaResp := &fwresource.SchemaResponse{}
+
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	return r, tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+}
+
+// testUnitState returns a state of the resource schema holding the model.
+func testUnitState(t *testing.T, empty tfsdk.State, model interface{}) tfsdk.State {
+	state := tfsdk.State{Schema: empty.Schema, Raw: empty.Raw.Copy()}
+
+	if diags := state.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("unable to build state: %v", diags)
+	}
+
+	return state
+}
+
+// testUnitTimeouts returns an unset timeouts block, all the operations
+// are included unless some are given.
+func testUnitTimeouts(operations ...string) timeouts.Value {
+	if len(operations) == 0 {
+		operations = []string{"create", "read", "update", "delete"}
+	}
+
+	attributes := map[string]attr.Type{}
+
+	for _, operation := range operations {
+		attributes[operation] = types.StringType
+	}
+
+	return timeouts.Value{
+		Object: types.ObjectNull(attributes),
+	}
 }
@@ -0,0 +1,62 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SlugifyFunction{}
+
+func NewSlugifyFunction() function.Function {
+	return &SlugifyFunction{}
+}
+
+// SlugifyFunction defines the function implementation.
+type SlugifyFunction struct{}
+
+func (f *SlugifyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "slugify"
+}
+
+func (f *SlugifyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Slug of a name accepted by Lynx",
+		MarkdownDescription: "Returns a slug accepted by Lynx for teams, projects and environments. Accents are removed, " +
+			"letters are lowercased and any other character becomes a single hyphen, so `Café Déjà Vu!` becomes `cafe-deja-vu`. " +
+			"Slugs are truncated to 60 characters and must have at least 2.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "Name to build the slug from",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SlugifyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name))
+
+	if resp.Error != nil {
+		return
+	}
+
+	slug, err := sdk.Slugify(name)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, slug))
+}
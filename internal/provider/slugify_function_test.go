@@ -0,0 +1,106 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSlugifyFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Provider defined functions are supported since Terraform 1.8
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "slug" {
+  value = provider::lynx::slugify("  Grafana Déjà Vu (Prod)! ")
+}
+`,
+				Check: resource.TestCheckOutput("slug", "grafana-deja-vu-prod"),
+			},
+			{
+				Config: `
+output "slug" {
+  value = provider::lynx::slugify("!!!")
+}
+`,
+				ExpectError: regexp.MustCompile("unable to build a slug"),
+			},
+		},
+	})
+}
+
+func TestAccSlugifyFunctionResources(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The fake Lynx API rejects the slugs Lynx rejects
+			{
+				Config: testAccProviderConfig(server) + `
+resource "lynx_team" "test" {
+  name        = "Monitoring"
+  slug        = "Monitoring"
+  description = "System Monitoring Team"
+  members     = []
+}
+`,
+				ExpectError: regexp.MustCompile("Slug Monitoring is invalid"),
+			},
+			// Lynx accepts the slugs built by the function
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "lynx_team" "test" {
+  name        = "Équipe Monitoring (Ops)"
+  slug        = provider::lynx::slugify("Équipe Monitoring (Ops)")
+  description = "System Monitoring Team"
+  members     = []
+}
+
+resource "lynx_project" "test" {
+  name        = "Grafana Straße"
+  slug        = provider::lynx::slugify("Grafana Straße")
+  description = "Grafana Project"
+
+  team = {
+    id = lynx_team.test.id
+  }
+}
+
+resource "lynx_environment" "test" {
+  name     = %[1]q
+  slug     = provider::lynx::slugify(%[1]q)
+  username = "admin"
+  secret   = "~secret-here~"
+
+  project = {
+    id = lynx_project.test.id
+  }
+}
+`, "Production "+strings.Repeat("Région ", 10)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lynx_team.test", "slug", "equipe-monitoring-ops"),
+					resource.TestCheckResourceAttr("lynx_project.test", "slug", "grafana-strasse"),
+					resource.TestCheckResourceAttr("lynx_environment.test", "slug", "production-region-region-region-region-region-region-region"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,310 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SnapshotExportResource{}
+
+func NewSnapshotExportResource() resource.Resource {
+	return &SnapshotExportResource{}
+}
+
+// SnapshotExportResource defines the resource implementation.
+type SnapshotExportResource struct {
+	client sdk.LynxAPI
+}
+
+// SnapshotExportResourceModel describes the resource data model.
+type SnapshotExportResourceModel struct {
+	ID         types.String   `tfsdk:"id"`
+	SnapshotID types.String   `tfsdk:"snapshot_id"`
+	Path       types.String   `tfsdk:"path"`
+	SHA256     types.String   `tfsdk:"sha256"`
+	Size       types.Int64    `tfsdk:"size"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *SnapshotExportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_export"
+}
+
+func (r *SnapshotExportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Downloads the data of a snapshot into a local file. " +
+			"The file is written again when it is modified or deleted outside of Terraform",
+		Attributes: map[string]schema.Attribute{
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the exported snapshot",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path of the local file, missing directories are created",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 checksum of the exported data",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "Size of the exported data in bytes",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Export identifier, the snapshot identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *SnapshotExportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(sdk.LynxAPI)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected sdk.LynxAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SnapshotExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SnapshotExportResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, fmt.Sprintf("Export a snapshot with id %s into %s", data.SnapshotID.ValueString(), data.Path.ValueString()))
+
+	download, err := r.export(ctx, data.SnapshotID.ValueString(), data.Path.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to export snapshot, got error: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.SnapshotID.ValueString())
+	data.SHA256 = types.StringValue(download.SHA256)
+	data.Size = types.Int64Value(download.Size)
+
+	tflog.Info(ctx, fmt.Sprintf("Snapshot with id %s got exported with sha256 %s", data.SnapshotID.ValueString(), download.SHA256))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapshotExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SnapshotExportResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checksum, size, err := fileChecksum(data.Path.ValueString())
+
+	if errors.Is(err, fs.ErrNotExist) {
+		tflog.Warn(ctx, fmt.Sprintf("Snapshot export %s not found, removing from state", data.Path.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read snapshot export, got error: %s", err.Error()),
+		)
+		return
+	}
+
+	// A modified file is exported again on the next apply
+	if checksum != data.SHA256.ValueString() {
+		tflog.Warn(ctx, fmt.Sprintf("Snapshot export %s checksum changed to %s, removing from state", data.Path.ValueString(), checksum))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Size = types.Int64Value(size)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapshotExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SnapshotExportResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state SnapshotExportResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All the attributes require a replacement, so only the timeouts block
+	// can change in place and the file stays the same
+	data.Size = state.Size
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapshotExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SnapshotExportResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Delete the snapshot export %s", data.Path.ValueString()))
+
+	err := os.Remove(data.Path.ValueString())
+
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete snapshot export, got error: %s", err.Error()),
+		)
+		return
+	}
+}
+
+// export downloads the snapshot into a temporary file next to the path
+// and renames it, so an interrupted download never leaves a partial file
+func (r *SnapshotExportResource) export(ctx context.Context, snapshotId, path string) (*sdk.SnapshotDownload, error) {
+	dir := filepath.Dir(path)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.CreateTemp(dir, ".lynx-snapshot-*")
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.Remove(file.Name())
+
+	download, err := r.client.DownloadSnapshot(ctx, snapshotId, file)
+
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return nil, err
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return nil, err
+	}
+
+	return download, nil
+}
+
+// fileChecksum computes the SHA-256 checksum and the size of a file
+func fileChecksum(path string) (string, int64, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return "", 0, err
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+
+	size, err := io.Copy(hash, file)
+
+	if err != nil {
+		return "", 0, err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
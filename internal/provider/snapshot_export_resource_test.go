@@ -0,0 +1,106 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSnapshotExportResource(t *testing.T) {
+	server := testAccServer(t)
+	path := filepath.Join(t.TempDir(), "exports", "grafana.json")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				return fmt.Errorf("expected %s to be deleted, got: %v", path, err)
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccSnapshotExportResourceConfig(path),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("lynx_snapshot_export.test", "id", "lynx_snapshot.test", "id"),
+					resource.TestCheckResourceAttrWith("lynx_snapshot_export.test", "sha256", testAccCheckFileChecksum(path)),
+					resource.TestCheckResourceAttrSet("lynx_snapshot_export.test", "size"),
+				),
+			},
+			// A modified file is exported again
+			{
+				PreConfig: func() {
+					if err := os.WriteFile(path, []byte("tampered"), 0o644); err != nil {
+						t.Fatalf("unable to modify export: %s", err)
+					}
+				},
+				Config: testAccProviderConfig(server) + testAccSnapshotExportResourceConfig(path),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("lynx_snapshot_export.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttrWith("lynx_snapshot_export.test", "sha256", testAccCheckFileChecksum(path)),
+			},
+			// A download not matching the server checksum is rejected
+			{
+				PreConfig: func() {
+					server.CorruptDownloads(true)
+
+					if err := os.Remove(path); err != nil {
+						t.Fatalf("unable to delete export: %s", err)
+					}
+				},
+				Config:      testAccProviderConfig(server) + testAccSnapshotExportResourceConfig(path),
+				ExpectError: regexp.MustCompile("checksum mismatch"),
+			},
+			// A deleted file is exported again
+			{
+				PreConfig: func() {
+					server.CorruptDownloads(false)
+				},
+				Config: testAccProviderConfig(server) + testAccSnapshotExportResourceConfig(path),
+				Check:  resource.TestCheckResourceAttrWith("lynx_snapshot_export.test", "sha256", testAccCheckFileChecksum(path)),
+			},
+		},
+	})
+}
+
+// testAccCheckFileChecksum checks the attribute against the file checksum.
+func testAccCheckFileChecksum(path string) resource.CheckResourceAttrWithFunc {
+	return func(value string) error {
+		checksum, _, err := fileChecksum(path)
+
+		if err != nil {
+			return err
+		}
+
+		if value != checksum {
+			return fmt.Errorf("expected sha256 %s, got %s", checksum, value)
+		}
+
+		return nil
+	}
+}
+
+func testAccSnapshotExportResourceConfig(path string) string {
+	return testAccSnapshotResourceConfig("Grafana Project Snapshot") + fmt.Sprintf(`
+resource "lynx_snapshot_export" "test" {
+  snapshot_id = lynx_snapshot.test.id
+  path        = %[1]q
+}
+`, path)
+}
@@ -10,11 +10,15 @@ import (
 
 	"github.com/clivern/terraform-provider-lynx/sdk"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -29,7 +33,7 @@ func NewSnapshotResource() resource.Resource {
 
 // SnapshotResource defines the resource implementation.
 type SnapshotResource struct {
-	client *sdk.Client
+	client sdk.LynxAPI
 }
 
 // SnapshotResourceModel describes the resource data model.
@@ -40,6 +44,10 @@ type SnapshotResourceModel struct {
 	RecordType  types.String            `tfsdk:"record_type"`
 	RecordID    types.String            `tfsdk:"record_id"`
 	Team        *TeamResourceSmallModel `tfsdk:"team"`
+	SourceFile  types.String            `tfsdk:"source_file"`
+	Status      types.String            `tfsdk:"status"`
+	CreatedAt   types.String            `tfsdk:"created_at"`
+	Timeouts    timeouts.Value          `tfsdk:"timeouts"`
 }
 
 func (r *SnapshotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,28 +55,48 @@ func (r *SnapshotResource) Metadata(ctx context.Context, req resource.MetadataRe
 }
 
 func (r *SnapshotResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Lynx can't update a snapshot, so changing any of its attributes
+	// takes a new snapshot
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Snapshot resource",
+		MarkdownDescription: "Snapshot resource. Snapshots are immutable, changing any attribute replaces the snapshot",
 		Attributes: map[string]schema.Attribute{
 			"title": schema.StringAttribute{
 				MarkdownDescription: "Snapshot's title",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Snapshot's description",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"record_type": schema.StringAttribute{
-				MarkdownDescription: "Snapshot's record_type",
+				MarkdownDescription: "Snapshot's record_type, either `project` or `environment`",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(sdk.ProjectRecord, sdk.EnvironmentRecord),
+				},
 			},
 			"record_id": schema.StringAttribute{
 				MarkdownDescription: "Snapshot's record_id",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"team": schema.SingleNestedAttribute{
 				MarkdownDescription: "Snapshot's team",
 				Required:            true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
 				Attributes: map[string]schema.Attribute{
 					"id": schema.StringAttribute{
 						Required:            true,
@@ -76,6 +104,27 @@ func (r *SnapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 					},
 				},
 			},
+			"source_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a snapshot archive, like one written by `lynx_snapshot_export`, to upload instead of taking the snapshot of the live record",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Snapshot's status, `success` once the snapshot is taken",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Time the snapshot was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Snapshot identifier",
@@ -84,6 +133,14 @@ func (r *SnapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -93,12 +150,12 @@ func (r *SnapshotResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.Client)
+	client, ok := req.ProviderData.(sdk.LynxAPI)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected sdk.LynxAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -117,6 +174,17 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	newSnapshot := sdk.Snapshot{
 		Title:       data.Title.ValueString(),
 		Description: data.Description.ValueString(),
@@ -127,9 +195,18 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 		},
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Create a snapshot with title %s", newSnapshot.Title))
+	var createdSnapshot *sdk.Snapshot
+	var err error
+
+	if data.SourceFile.ValueString() != "" {
+		tflog.Info(ctx, fmt.Sprintf("Upload a snapshot with title %s from %s", newSnapshot.Title, data.SourceFile.ValueString()))
 
-	createdSnapshot, err := r.client.CreateSnapshot(newSnapshot)
+		createdSnapshot, err = r.client.UploadSnapshot(ctx, newSnapshot, data.SourceFile.ValueString())
+	} else {
+		tflog.Info(ctx, fmt.Sprintf("Create a snapshot with title %s", newSnapshot.Title))
+
+		createdSnapshot, err = r.client.CreateSnapshot(ctx, newSnapshot)
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -139,7 +216,14 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Snapshot with title %s got created", newSnapshot.Title))
+	tflog.Info(ctx, fmt.Sprintf("Wait for the snapshot with id %s to be taken", createdSnapshot.ID))
+
+	// Lynx takes the snapshot in the background
+	takenSnapshot, err := sdk.WaitForSnapshot(ctx, r.client, createdSnapshot.ID)
+
+	if takenSnapshot != nil {
+		createdSnapshot = takenSnapshot
+	}
 
 	// Set the created snapshot's ID in the Terraform state
 	data.ID = types.StringValue(createdSnapshot.ID)
@@ -147,9 +231,22 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 	data.RecordType = types.StringValue(createdSnapshot.RecordType)
 	data.RecordID = types.StringValue(createdSnapshot.RecordID)
 	data.Team.ID = types.StringValue(createdSnapshot.Team.ID)
+	data.Status = types.StringValue(createdSnapshot.Status)
+	data.CreatedAt = types.StringValue(createdSnapshot.CreatedAt)
 
-	// Save data into Terraform state
+	// A failed or unfinished snapshot is still saved, so Terraform taints
+	// it and takes a new one on the next apply
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to take snapshot, got error: %s", err.Error()),
+		)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Snapshot with title %s got created", newSnapshot.Title))
 }
 
 func (r *SnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -162,10 +259,28 @@ func (r *SnapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, fmt.Sprintf("Read a snapshot with id %s", data.ID.ValueString()))
 
 	// Retrieve the snapshot using the GetSnapshot method
-	snapshot, err := r.client.GetSnapshot(data.ID.ValueString())
+	snapshot, err := r.client.GetSnapshot(ctx, data.ID.ValueString())
+
+	if sdk.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Snapshot with id %s not found, removing it from state", data.ID.ValueString()))
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -177,9 +292,11 @@ func (r *SnapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	// Update the data model with the retrieved snapshot information
 	data.Title = types.StringValue(snapshot.Title)
+	data.Description = types.StringValue(snapshot.Description)
+	data.Status = types.StringValue(snapshot.Status)
+	data.CreatedAt = types.StringValue(snapshot.CreatedAt)
 	data.RecordType = types.StringValue(snapshot.RecordType)
 	data.RecordID = types.StringValue(snapshot.RecordID)
-	data.Team.ID = types.StringValue(snapshot.Team.ID)
 
 	// Update the nested team data
 	data.Team = &TeamResourceSmallModel{
@@ -200,6 +317,10 @@ func (r *SnapshotResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	// All the snapshot attributes require a replacement, so only the
+	// timeouts block can change in place and there is nothing to send
+	tflog.Info(ctx, fmt.Sprintf("Update the timeouts of a snapshot with id %s", data.ID.ValueString()))
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -214,11 +335,22 @@ func (r *SnapshotResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, fmt.Sprintf("Delete a snapshot with id %s", data.ID.ValueString()))
 
-	err := r.client.DeleteSnapshot(data.ID.ValueString())
+	err := r.client.DeleteSnapshot(ctx, data.ID.ValueString())
 
-	if err != nil {
+	if err != nil && !sdk.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete snapshot, got error: %s", err.Error()),
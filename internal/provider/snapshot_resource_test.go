@@ -0,0 +1,199 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxtest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSnapshotResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSnapshotDestroy(server),
+		Steps: []resource.TestStep{
+			// Unsupported record type
+			{
+				Config: testAccProviderConfig(server) + strings.Replace(
+					testAccSnapshotResourceConfig("Grafana Project Snapshot"),
+					`record_type = "project"`,
+					`record_type = "team"`,
+					1,
+				),
+				ExpectError: regexp.MustCompile(`Attribute record_type value must be one of`),
+			},
+			// A failed snapshot fails the apply
+			{
+				PreConfig: func() {
+					server.FailTasks("Disk is full")
+				},
+				Config:      testAccProviderConfig(server) + testAccSnapshotResourceConfig("Grafana Project Snapshot"),
+				ExpectError: regexp.MustCompile("Disk is full"),
+			},
+			// Create and Read testing, the failed snapshot is replaced
+			{
+				PreConfig: func() {
+					server.FailTasks("")
+				},
+				Config: testAccProviderConfig(server) + testAccSnapshotResourceConfig("Grafana Project Snapshot"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("lynx_snapshot.test", "id"),
+					resource.TestCheckResourceAttr("lynx_snapshot.test", "status", sdk.TaskSuccess),
+					resource.TestCheckResourceAttrSet("lynx_snapshot.test", "created_at"),
+					resource.TestCheckResourceAttr("lynx_snapshot.test", "title", "Grafana Project Snapshot"),
+					resource.TestCheckResourceAttr("lynx_snapshot.test", "record_type", "project"),
+					resource.TestCheckResourceAttrPair("lynx_snapshot.test", "record_id", "lynx_project.test", "id"),
+					resource.TestCheckResourceAttrPair("lynx_snapshot.test", "team.id", "lynx_team.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "lynx_snapshot.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Snapshots are immutable, so a change replaces the snapshot
+			{
+				Config: testAccProviderConfig(server) + testAccSnapshotResourceConfig("Grafana Snapshot"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("lynx_snapshot.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lynx_snapshot.test", "title", "Grafana Snapshot"),
+					func(s *terraform.State) error {
+						snapshots, err := sdk.IterateSnapshots(server.Client(), sdk.ListOptions{}).All(context.Background())
+
+						if err != nil || len(snapshots) != 1 || snapshots[0].Title != "Grafana Snapshot" {
+							return fmt.Errorf("expected only the new snapshot to exist, got: %v, %v", snapshots, err)
+						}
+
+						return nil
+					},
+				),
+			},
+			// Deleted outside of Terraform testing
+			{
+				PreConfig: func() {
+					snapshot := testAccFindSnapshot(t, server, "Grafana Snapshot")
+
+					if err := server.Client().DeleteSnapshot(context.Background(), snapshot.ID); err != nil {
+						t.Fatalf("unable to delete snapshot: %s", err)
+					}
+				},
+				Config:             testAccProviderConfig(server) + testAccSnapshotResourceConfig("Grafana Snapshot"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSnapshotResourceSourceFile(t *testing.T) {
+	server := testAccServer(t)
+	dir := t.TempDir()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSnapshotDestroy(server),
+		Steps: []resource.TestStep{
+			// The exported snapshot is uploaded back as it is
+			{
+				Config: testAccProviderConfig(server) + testAccSnapshotExportResourceConfig(filepath.Join(dir, "grafana.json")) + fmt.Sprintf(`
+resource "lynx_snapshot" "upload" {
+  title       = "Grafana Project Upload"
+  description = "Grafana Project Upload"
+  record_type = "project"
+  record_id   = lynx_project.test.id
+  source_file = lynx_snapshot_export.test.path
+
+  team = {
+    id = lynx_team.test.id
+  }
+}
+
+resource "lynx_snapshot_export" "upload" {
+  snapshot_id = lynx_snapshot.upload.id
+  path        = %[1]q
+}
+`, filepath.Join(dir, "upload.json")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lynx_snapshot.upload", "status", sdk.TaskSuccess),
+					resource.TestCheckResourceAttr("lynx_snapshot.upload", "source_file", filepath.Join(dir, "grafana.json")),
+					resource.TestCheckResourceAttrPair("lynx_snapshot_export.upload", "sha256", "lynx_snapshot_export.test", "sha256"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSnapshotResourceConfig(title string) string {
+	return testAccProjectResourceConfig("Grafana") + fmt.Sprintf(`
+resource "lynx_snapshot" "test" {
+  title       = %[1]q
+  description = "Grafana Project Snapshot"
+  record_type = "project"
+  record_id   = lynx_project.test.id
+
+  team = {
+    id = lynx_team.test.id
+  }
+}
+`, title)
+}
+
+// testAccFindSnapshot finds a snapshot on the fake Lynx API by title.
+func testAccFindSnapshot(t *testing.T, server *lynxtest.Server, title string) sdk.Snapshot {
+	snapshots, err := sdk.IterateSnapshots(server.Client(), sdk.ListOptions{}).All(context.Background())
+
+	if err != nil {
+		t.Fatalf("unable to list snapshots: %s", err)
+	}
+
+	for _, snapshot := range snapshots {
+		if snapshot.Title == title {
+			return snapshot
+		}
+	}
+
+	t.Fatalf("snapshot %s not found", title)
+
+	return sdk.Snapshot{}
+}
+
+func testAccCheckSnapshotDestroy(server *lynxtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "lynx_snapshot" {
+				continue
+			}
+
+			_, err := server.Client().GetSnapshot(context.Background(), rs.Primary.ID)
+
+			if !sdk.IsNotFound(err) {
+				return fmt.Errorf("snapshot %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SnapshotRestoreResource{}
+
+func NewSnapshotRestoreResource() resource.Resource {
+	return &SnapshotRestoreResource{}
+}
+
+// SnapshotRestoreResource defines the resource implementation.
+type SnapshotRestoreResource struct {
+	client sdk.LynxAPI
+}
+
+// SnapshotRestoreResourceModel describes the resource data model.
+type SnapshotRestoreResourceModel struct {
+	ID         types.String   `tfsdk:"id"`
+	SnapshotID types.String   `tfsdk:"snapshot_id"`
+	RecordType types.String   `tfsdk:"record_type"`
+	RecordID   types.String   `tfsdk:"record_id"`
+	Triggers   types.Map      `tfsdk:"triggers"`
+	Status     types.String   `tfsdk:"status"`
+	Result     types.String   `tfsdk:"result"`
+	CreatedAt  types.String   `tfsdk:"created_at"`
+	UpdatedAt  types.String   `tfsdk:"updated_at"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *SnapshotRestoreResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_restore"
+}
+
+func (r *SnapshotRestoreResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Restores a snapshot into a project or an environment and waits for the restore task to finish. " +
+			"The restore runs again when any attribute changes, destroying the resource doesn't undo the restore",
+		Attributes: map[string]schema.Attribute{
+			"snapshot_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the snapshot to restore",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"record_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the restored record, either `project` or `environment`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(sdk.ProjectRecord, sdk.EnvironmentRecord),
+				},
+			},
+			"record_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the restored record",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that run the restore again when they change",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the restore task",
+				Computed:            true,
+			},
+			"result": schema.StringAttribute{
+				MarkdownDescription: "Result message of the restore task",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Time the restore task was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "Time the restore task was last updated",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Restore task identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+			}),
+		},
+	}
+}
+
+func (r *SnapshotRestoreResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(sdk.LynxAPI)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected sdk.LynxAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SnapshotRestoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SnapshotRestoreResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, fmt.Sprintf("Restore a snapshot with id %s into %s %s", data.SnapshotID.ValueString(), data.RecordType.ValueString(), data.RecordID.ValueString()))
+
+	task, err := r.client.RestoreSnapshot(ctx, data.SnapshotID.ValueString(), sdk.SnapshotRestore{
+		RecordType: data.RecordType.ValueString(),
+		RecordID:   data.RecordID.ValueString(),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to restore snapshot, got error: %s", err.Error()),
+		)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Wait for the restore task with id %s", task.ID))
+
+	done, err := sdk.WaitForTask(ctx, r.client, task.ID)
+
+	if done != nil {
+		task = done
+	}
+
+	r.setTask(&data, task)
+
+	// A failed or unfinished restore is still saved, so Terraform taints
+	// it and runs the restore again on the next apply
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to restore snapshot, got error: %s", err.Error()),
+		)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Snapshot with id %s got restored", data.SnapshotID.ValueString()))
+}
+
+func (r *SnapshotRestoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SnapshotRestoreResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	tflog.Info(ctx, fmt.Sprintf("Read a restore task with id %s", data.ID.ValueString()))
+
+	task, err := r.client.GetTask(ctx, data.ID.ValueString())
+
+	// Lynx purges old tasks, the restore still happened so keep it in
+	// state instead of restoring again
+	if sdk.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Restore task with id %s not found, keeping the last known status", data.ID.ValueString()))
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read restore task, got error: %s", err.Error()),
+		)
+		return
+	}
+
+	r.setTask(&data, task)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapshotRestoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SnapshotRestoreResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state SnapshotRestoreResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All the attributes require a replacement, so only the timeouts block
+	// can change in place and the task stays the same
+	data.Status = state.Status
+	data.Result = state.Result
+	data.UpdatedAt = state.UpdatedAt
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapshotRestoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SnapshotRestoreResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A restore can't be undone, so it is only removed from state
+	tflog.Info(ctx, fmt.Sprintf("Remove the restore task with id %s from state", data.ID.ValueString()))
+}
+
+// setTask copies the restore task into the model
+func (r *SnapshotRestoreResource) setTask(data *SnapshotRestoreResourceModel, task *sdk.Task) {
+	data.ID = types.StringValue(task.ID)
+	data.Status = types.StringValue(task.Status)
+	data.Result = types.StringValue(task.Result)
+	data.CreatedAt = types.StringValue(task.CreatedAt)
+	data.UpdatedAt = types.StringValue(task.UpdatedAt)
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxmock"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccSnapshotRestoreResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccSnapshotRestoreResourceConfig("1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("lynx_snapshot_restore.test", "id"),
+					resource.TestCheckResourceAttr("lynx_snapshot_restore.test", "status", sdk.TaskSuccess),
+					resource.TestCheckResourceAttr("lynx_snapshot_restore.test", "result", "Snapshot restored"),
+					resource.TestCheckResourceAttrSet("lynx_snapshot_restore.test", "created_at"),
+					resource.TestCheckResourceAttrSet("lynx_snapshot_restore.test", "updated_at"),
+					resource.TestCheckResourceAttrPair("lynx_snapshot_restore.test", "snapshot_id", "lynx_snapshot.test", "id"),
+				),
+			},
+			// Changing the triggers restores the snapshot again
+			{
+				Config: testAccProviderConfig(server) + testAccSnapshotRestoreResourceConfig("2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("lynx_snapshot_restore.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("lynx_snapshot_restore.test", "status", sdk.TaskSuccess),
+			},
+			// A failed restore is reported
+			{
+				PreConfig: func() {
+					server.FailTasks("Project grafana is locked")
+				},
+				Config:      testAccProviderConfig(server) + testAccSnapshotRestoreResourceConfig("3"),
+				ExpectError: regexp.MustCompile("Project grafana is locked"),
+			},
+		},
+	})
+}
+
+func TestUnitSnapshotRestoreResourceReadNotFound(t *testing.T) {
+	client := &lynxmock.Client{
+		GetTaskFunc: func(ctx context.Context, taskId string) (*sdk.Task, error) {
+			return nil, &sdk.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+
+	r, empty := testUnitResource(t, NewSnapshotRestoreResource, client)
+
+	state := testUnitState(t, empty, SnapshotRestoreResourceModel{
+		ID:         types.StringValue("task-1"),
+		SnapshotID: types.StringValue("snapshot-1"),
+		RecordType: types.StringValue(sdk.ProjectRecord),
+		RecordID:   types.StringValue("project-1"),
+		Triggers:   types.MapNull(types.StringType),
+		Status:     types.StringValue(sdk.TaskSuccess),
+		Result:     types.StringValue("Snapshot restored"),
+		CreatedAt:  types.StringValue("2024-01-01T00:00:00Z"),
+		UpdatedAt:  types.StringValue("2024-01-01T00:00:00Z"),
+		Timeouts:   testUnitTimeouts("create", "read"),
+	})
+
+	resp := &fwresource.ReadResponse{State: state}
+
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected no error, got: %v", resp.Diagnostics)
+	}
+
+	if resp.State.Raw.IsNull() {
+		t.Error("expected a purged restore task to stay in state")
+	}
+}
+
+func testAccSnapshotRestoreResourceConfig(trigger string) string {
+	return testAccSnapshotResourceConfig("Grafana Project Snapshot") + fmt.Sprintf(`
+resource "lynx_snapshot_restore" "test" {
+  snapshot_id = lynx_snapshot.test.id
+  record_type = "project"
+  record_id   = lynx_project.test.id
+
+  triggers = {
+    run = %[1]q
+  }
+}
+`, trigger)
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxtest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccTeamMemberResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing, members are added in parallel
+			{
+				Config: testAccProviderConfig(server) + testAccTeamMemberResourceConfig("stella", "skylar"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("lynx_team.test", "members"),
+					resource.TestCheckResourceAttrPair("lynx_team_member.stella", "team_id", "lynx_team.test", "id"),
+					resource.TestCheckResourceAttrPair("lynx_team_member.stella", "user_id", "lynx_user.stella", "id"),
+					testAccCheckTeamMembers(t, server, "Stella", "Skylar"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "lynx_team_member.stella",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Members added outside of Terraform are kept
+			{
+				PreConfig: func() {
+					user, err := server.Client().CreateUser(context.Background(), sdk.User{Name: "Erika", Email: "erika@example.com", Role: sdk.RegularUser, Password: "password"})
+
+					if err != nil {
+						t.Fatalf("unable to create user: %s", err)
+					}
+
+					team := testAccFindTeam(t, server, "monitoring")
+					team.Members = append(team.Members, user.ID)
+
+					if _, err := server.Client().UpdateTeam(context.Background(), team); err != nil {
+						t.Fatalf("unable to update team: %s", err)
+					}
+				},
+				Config: testAccProviderConfig(server) + testAccTeamMemberResourceConfig("stella"),
+				Check:  testAccCheckTeamMembers(t, server, "Stella", "Erika"),
+			},
+			// Removed outside of Terraform testing
+			{
+				PreConfig: func() {
+					team := testAccFindTeam(t, server, "monitoring")
+					team.Members = []string{}
+
+					if _, err := server.Client().UpdateTeam(context.Background(), team); err != nil {
+						t.Fatalf("unable to update team: %s", err)
+					}
+				},
+				Config:             testAccProviderConfig(server) + testAccTeamMemberResourceConfig("stella"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccTeamMemberResourceLastMember(t *testing.T) {
+	server := testAccServer(t)
+	server.PartialTeamUpdates(true)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccTeamMemberResourceConfig("stella"),
+				Check:  testAccCheckTeamMembers(t, server, "Stella"),
+			},
+			// Removing the last member empties the team even if the API keeps unsent fields
+			{
+				Config: testAccProviderConfig(server) + testAccTeamMemberResourceConfig(),
+				Check:  testAccCheckTeamMembers(t, server),
+			},
+		},
+	})
+}
+
+// testAccCheckTeamMembers checks the team members names on the fake Lynx API.
+func testAccCheckTeamMembers(t *testing.T, server *lynxtest.Server, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		team := testAccFindTeam(t, server, "monitoring")
+
+		members := []string{}
+
+		for _, id := range team.Members {
+			user, err := server.Client().GetUser(context.Background(), id)
+
+			if err != nil {
+				return err
+			}
+
+			members = append(members, user.Name)
+		}
+
+		slices.Sort(members)
+		slices.Sort(names)
+
+		if !slices.Equal(members, names) {
+			return fmt.Errorf("expected team members %v, got %v", names, members)
+		}
+
+		return nil
+	}
+}
+
+func testAccTeamMemberResourceConfig(users ...string) string {
+	config := testAccUsersConfig() + `
+resource "lynx_team" "test" {
+  name        = "Monitoring"
+  slug        = "monitoring"
+  description = "System Monitoring Team"
+}
+`
+
+	for _, user := range users {
+		config += fmt.Sprintf(`
+resource "lynx_team_member" %[1]q {
+  team_id = lynx_team.test.id
+  user_id = lynx_user.%[1]s.id
+}
+`, user)
+	}
+
+	return config
+}
@@ -7,10 +7,14 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
+	"slices"
+	"sync"
 
 	"github.com/clivern/terraform-provider-lynx/sdk"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -23,6 +27,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TeamResource{}
 var _ resource.ResourceWithImportState = &TeamResource{}
+var _ resource.ResourceWithUpgradeState = &TeamResource{}
 
 func NewTeamResource() resource.Resource {
 	return &TeamResource{}
@@ -30,16 +35,28 @@ func NewTeamResource() resource.Resource {
 
 // TeamResource defines the resource implementation.
 type TeamResource struct {
-	client *sdk.Client
+	client sdk.LynxAPI
 }
 
 // TeamResourceModel describes the resource data model.
 type TeamResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Slug        types.String `tfsdk:"slug"`
-	Description types.String `tfsdk:"description"`
-	Members     types.List   `tfsdk:"members"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Slug        types.String   `tfsdk:"slug"`
+	Description types.String   `tfsdk:"description"`
+	Members     types.Set      `tfsdk:"members"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// TeamResourceModelV0 describes the resource data model of the schema
+// version 0 storing the members as a list.
+type TeamResourceModelV0 struct {
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Slug        types.String   `tfsdk:"slug"`
+	Description types.String   `tfsdk:"description"`
+	Members     types.List     `tfsdk:"members"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *TeamResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,6 +68,9 @@ func (r *TeamResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Team resource",
 
+		// Version 1 stores the members as a set
+		Version: 1,
+
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Team's name",
@@ -64,10 +84,10 @@ func (r *TeamResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "Team's description",
 				Optional:            true,
 			},
-			"members": schema.ListAttribute{
-				MarkdownDescription: "Team's members",
+			"members": schema.SetAttribute{
+				MarkdownDescription: "Identifiers of the team's members. The list is authoritative, omit it to manage the members with `lynx_team_member` instead",
 				ElementType:         types.StringType,
-				Required:            true,
+				Optional:            true,
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -77,6 +97,14 @@ func (r *TeamResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -86,12 +114,12 @@ func (r *TeamResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.Client)
+	client, ok := req.ProviderData.(sdk.LynxAPI)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected sdk.LynxAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -110,14 +138,23 @@ func (r *TeamResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	membersList := data.Members.Elements()
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	members := make([]string, 0, len(membersList))
+	var members []string
 
-	for i := 0; i < len(membersList); i++ {
-		member := membersList[i]
+	resp.Diagnostics.Append(data.Members.ElementsAs(ctx, &members, false)...)
 
-		members = append(members, strings.Trim(member.String(), "\""))
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	newTeam := sdk.Team{
@@ -129,7 +166,7 @@ func (r *TeamResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	tflog.Info(ctx, fmt.Sprintf("Create a team with name %s", newTeam.Name))
 
-	createdTeam, err := r.client.CreateTeam(newTeam)
+	createdTeam, err := r.client.CreateTeam(ctx, newTeam)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -158,10 +195,28 @@ func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, fmt.Sprintf("Read a team with id %s", data.ID.ValueString()))
 
 	// Retrieve the team using the GetTeam method
-	team, err := r.client.GetTeam(data.ID.ValueString())
+	team, err := r.client.GetTeam(ctx, data.ID.ValueString())
+
+	if sdk.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Team with id %s not found, removing it from state", data.ID.ValueString()))
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -171,13 +226,33 @@ func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	members, _ := types.ListValueFrom(context.TODO(), types.StringType, team.Members)
-
 	// Update the data model with the retrieved team information
 	data.Name = types.StringValue(team.Name)
 	data.Slug = types.StringValue(team.Slug)
-	data.Description = types.StringValue(team.Description)
-	data.Members = members
+
+	// Unmanaged members stay null, so lynx_team_member changes don't show
+	// up as drift
+	if !data.Members.IsNull() {
+		// The API omits the members of an empty team
+		if team.Members == nil {
+			team.Members = []string{}
+		}
+
+		members, diags := types.SetValueFrom(ctx, types.StringType, team.Members)
+
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Members = members
+	}
+
+	// Keep an omitted description null since the API returns it empty
+	if team.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(team.Description)
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -193,14 +268,40 @@ func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	membersList := data.Members.Elements()
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
 
-	members := make([]string, 0, len(membersList))
+	resp.Diagnostics.Append(diags...)
 
-	for i := 0; i < len(membersList); i++ {
-		member := membersList[i]
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	defer lockTeam(data.ID.ValueString())()
+
+	var members []string
 
-		members = append(members, member.String())
+	resp.Diagnostics.Append(data.Members.ElementsAs(ctx, &members, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unmanaged members are kept as they are on the server
+	if data.Members.IsNull() {
+		currentTeam, err := r.client.GetTeam(ctx, data.ID.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to read team, got error: %s", err.Error()),
+			)
+			return
+		}
+
+		members = currentTeam.Members
 	}
 
 	// Update the team using the UpdateTeam method
@@ -214,7 +315,7 @@ func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	tflog.Info(ctx, fmt.Sprintf("Update a team with id %s", updatedTeam.ID))
 
-	_, err := r.client.UpdateTeam(updatedTeam)
+	_, err := r.client.UpdateTeam(ctx, updatedTeam)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -240,11 +341,22 @@ func (r *TeamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, fmt.Sprintf("Delete a team with id %s", data.ID.ValueString()))
 
-	err := r.client.DeleteTeam(data.ID.ValueString())
+	err := r.client.DeleteTeam(ctx, data.ID.ValueString())
 
-	if err != nil {
+	if err != nil && !sdk.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete team, got error: %s", err.Error()),
@@ -257,4 +369,100 @@ func (r *TeamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 func (r *TeamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	// Imported teams manage their members, so the read fills them in
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("members"), types.SetValueMust(types.StringType, []attr.Value{}))...)
+}
+
+func (r *TeamResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Required: true,
+					},
+					"slug": schema.StringAttribute{
+						Required: true,
+					},
+					"description": schema.StringAttribute{
+						Optional: true,
+					},
+					"members": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"timeouts": timeouts.Block(ctx, timeouts.Opts{
+						Create: true,
+						Read:   true,
+						Update: true,
+						Delete: true,
+					}),
+				},
+			},
+			StateUpgrader: upgradeTeamStateV0,
+		},
+	}
+}
+
+// upgradeTeamStateV0 converts the members list of the schema version 0
+// into a set, dropping the duplicated members the API ignores
+func upgradeTeamStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior TeamResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members := types.SetNull(types.StringType)
+
+	if !prior.Members.IsNull() {
+		var elements []string
+
+		resp.Diagnostics.Append(prior.Members.ElementsAs(ctx, &elements, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		slices.Sort(elements)
+
+		var diags diag.Diagnostics
+
+		members, diags = types.SetValueFrom(ctx, types.StringType, slices.Compact(elements))
+
+		resp.Diagnostics.Append(diags...)
+	}
+
+	data := TeamResourceModel{
+		ID:          prior.ID,
+		Name:        prior.Name,
+		Slug:        prior.Slug,
+		Description: prior.Description,
+		Members:     members,
+		Timeouts:    prior.Timeouts,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// teamLocks serializes the changes of a team members. The API replaces
+// the whole team, so concurrent changes would drop members
+var teamLocks sync.Map
+
+// lockTeam locks the members of a team and returns the unlock function
+func lockTeam(teamId string) func() {
+	value, _ := teamLocks.LoadOrStore(teamId, &sync.Mutex{})
+	mutex := value.(*sync.Mutex)
+
+	mutex.Lock()
+
+	return mutex.Unlock
 }
@@ -0,0 +1,316 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxmock"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxtest"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccTeamResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTeamDestroy(server),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccTeamResourceConfig("Monitoring", "lynx_user.stella.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("lynx_team.test", "id"),
+					resource.TestCheckResourceAttr("lynx_team.test", "name", "Monitoring"),
+					resource.TestCheckResourceAttr("lynx_team.test", "slug", "monitoring"),
+					resource.TestCheckResourceAttr("lynx_team.test", "members.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("lynx_team.test", "members.*", "lynx_user.stella", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "lynx_team.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccTeamResourceConfig("Monitoring Team", "lynx_user.stella.id, lynx_user.skylar.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lynx_team.test", "name", "Monitoring Team"),
+					resource.TestCheckResourceAttr("lynx_team.test", "members.#", "2"),
+				),
+			},
+			// The members order returned by the API doesn't matter
+			{
+				PreConfig: func() {
+					team := testAccFindTeam(t, server, "monitoring")
+					slices.Reverse(team.Members)
+
+					if _, err := server.Client().UpdateTeam(context.Background(), team); err != nil {
+						t.Fatalf("unable to update team: %s", err)
+					}
+				},
+				Config:   testAccProviderConfig(server) + testAccTeamResourceConfig("Monitoring Team", "lynx_user.stella.id, lynx_user.skylar.id"),
+				PlanOnly: true,
+			},
+			// Drift detection testing
+			{
+				PreConfig: func() {
+					team := testAccFindTeam(t, server, "monitoring")
+					team.Description = "Changed Outside"
+
+					if _, err := server.Client().UpdateTeam(context.Background(), team); err != nil {
+						t.Fatalf("unable to update team: %s", err)
+					}
+				},
+				Config:             testAccProviderConfig(server) + testAccTeamResourceConfig("Monitoring Team", "lynx_user.stella.id, lynx_user.skylar.id"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Deleted outside of Terraform testing
+			{
+				PreConfig: func() {
+					team := testAccFindTeam(t, server, "monitoring")
+
+					if err := server.Client().DeleteTeam(context.Background(), team.ID); err != nil {
+						t.Fatalf("unable to delete team: %s", err)
+					}
+				},
+				Config:             testAccProviderConfig(server) + testAccTeamResourceConfig("Monitoring Team", "lynx_user.stella.id, lynx_user.skylar.id"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccTeamResourceClearMembers(t *testing.T) {
+	server := testAccServer(t)
+	server.PartialTeamUpdates(true)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccTeamResourceConfig("Monitoring", "lynx_user.stella.id"),
+				Check:  resource.TestCheckResourceAttr("lynx_team.test", "members.#", "1"),
+			},
+			// An empty set clears the members even if the API keeps unsent fields
+			{
+				Config: testAccProviderConfig(server) + testAccTeamResourceConfig("Monitoring", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lynx_team.test", "members.#", "0"),
+					testAccCheckTeamMembers(t, server),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitTeamResourceUpdateMembers(t *testing.T) {
+	var updated sdk.Team
+
+	client := &lynxmock.Client{
+		UpdateTeamFunc: func(ctx context.Context, team sdk.Team) (*sdk.Team, error) {
+			updated = team
+			return &team, nil
+		},
+	}
+
+	r, empty := testUnitResource(t, NewTeamResource, client)
+
+	members, _ := types.SetValueFrom(context.Background(), types.StringType, []string{"user-1", "user-2"})
+
+	plan := testUnitState(t, empty, TeamResourceModel{
+		ID:          types.StringValue("team-1"),
+		Name:        types.StringValue("Monitoring"),
+		Slug:        types.StringValue("monitoring"),
+		Description: types.StringNull(),
+		Members:     members,
+		Timeouts:    testUnitTimeouts(),
+	})
+
+	resp := &fwresource.UpdateResponse{State: empty}
+
+	r.Update(context.Background(), fwresource.UpdateRequest{Plan: tfsdk.Plan(plan), State: plan}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected no error, got: %v", resp.Diagnostics)
+	}
+
+	if !reflect.DeepEqual(updated.Members, []string{"user-1", "user-2"}) {
+		t.Errorf("expected members to be sent as plain identifiers, got: %q", updated.Members)
+	}
+}
+
+func TestUnitTeamResourceUpdateUnmanagedMembers(t *testing.T) {
+	var updated sdk.Team
+
+	client := &lynxmock.Client{
+		GetTeamFunc: func(ctx context.Context, teamId string) (*sdk.Team, error) {
+			return &sdk.Team{ID: teamId, Name: "Monitoring", Slug: "monitoring", Members: []string{"user-1"}}, nil
+		},
+		UpdateTeamFunc: func(ctx context.Context, team sdk.Team) (*sdk.Team, error) {
+			updated = team
+			return &team, nil
+		},
+	}
+
+	r, empty := testUnitResource(t, NewTeamResource, client)
+
+	plan := testUnitState(t, empty, TeamResourceModel{
+		ID:          types.StringValue("team-1"),
+		Name:        types.StringValue("Monitoring Team"),
+		Slug:        types.StringValue("monitoring"),
+		Description: types.StringNull(),
+		Members:     types.SetNull(types.StringType),
+		Timeouts:    testUnitTimeouts(),
+	})
+
+	resp := &fwresource.UpdateResponse{State: empty}
+
+	r.Update(context.Background(), fwresource.UpdateRequest{Plan: tfsdk.Plan(plan), State: plan}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected no error, got: %v", resp.Diagnostics)
+	}
+
+	if !reflect.DeepEqual(updated.Members, []string{"user-1"}) {
+		t.Errorf("expected the server members to be kept, got: %q", updated.Members)
+	}
+}
+
+func TestUnitTeamResourceUpgradeState(t *testing.T) {
+	ctx := context.Background()
+	server, _ := providerserver.NewProtocol6WithError(New("test")())()
+	_, empty := testUnitResource(t, NewTeamResource, nil)
+
+	// State written by the provider before members became a set
+	upgradeResp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "lynx_team",
+		Version:  0,
+		RawState: &tfprotov6.RawState{
+			JSON: []byte(`{"id":"team-1","name":"Monitoring","slug":"monitoring","description":"System Monitoring Team","members":["user-2","user-1","user-1"]}`),
+		},
+	})
+
+	if err != nil || len(upgradeResp.Diagnostics) > 0 {
+		t.Fatalf("unable to upgrade state: %v %v", err, upgradeResp.Diagnostics)
+	}
+
+	members, _ := types.SetValueFrom(ctx, types.StringType, []string{"user-1", "user-2"})
+
+	model := TeamResourceModel{
+		ID:          types.StringNull(),
+		Name:        types.StringValue("Monitoring"),
+		Slug:        types.StringValue("monitoring"),
+		Description: types.StringValue("System Monitoring Team"),
+		Members:     members,
+		Timeouts:    testUnitTimeouts(),
+	}
+
+	config := testUnitState(t, empty, model)
+
+	model.ID = types.StringValue("team-1")
+
+	proposed := testUnitState(t, empty, model)
+
+	configValue, _ := tfprotov6.NewDynamicValue(empty.Raw.Type(), config.Raw)
+	proposedValue, _ := tfprotov6.NewDynamicValue(empty.Raw.Type(), proposed.Raw)
+
+	planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "lynx_team",
+		PriorState:       upgradeResp.UpgradedState,
+		ProposedNewState: &proposedValue,
+		Config:           &configValue,
+	})
+
+	if err != nil || len(planResp.Diagnostics) > 0 {
+		t.Fatalf("unable to plan: %v %v", err, planResp.Diagnostics)
+	}
+
+	prior, _ := upgradeResp.UpgradedState.Unmarshal(empty.Raw.Type())
+	planned, _ := planResp.PlannedState.Unmarshal(empty.Raw.Type())
+
+	if !planned.Equal(prior) || len(planResp.RequiresReplace) > 0 {
+		t.Errorf("expected no diff for the upgraded state %v, got: %v", prior, planned)
+	}
+}
+
+// testAccUsersConfig returns two users to use as team members.
+func testAccUsersConfig() string {
+	return `
+resource "lynx_user" "stella" {
+  name     = "Stella"
+  email    = "stella@example.com"
+  role     = "regular"
+  password = "~password-here~"
+}
+
+resource "lynx_user" "skylar" {
+  name     = "Skylar"
+  email    = "skylar@example.com"
+  role     = "regular"
+  password = "~password-here~"
+}
+`
+}
+
+func testAccTeamResourceConfig(name, members string) string {
+	return testAccUsersConfig() + fmt.Sprintf(`
+resource "lynx_team" "test" {
+  name        = %[1]q
+  slug        = "monitoring"
+  description = "System Monitoring Team"
+  members     = [%[2]s]
+}
+`, name, members)
+}
+
+// testAccFindTeam finds a team on the fake Lynx API by slug.
+func testAccFindTeam(t *testing.T, server *lynxtest.Server, slug string) sdk.Team {
+	teams, err := sdk.IterateTeams(server.Client(), sdk.ListOptions{Slug: slug}).All(context.Background())
+
+	if err != nil || len(teams) != 1 {
+		t.Fatalf("unable to find team %s: %v", slug, err)
+	}
+
+	return teams[0]
+}
+
+func testAccCheckTeamDestroy(server *lynxtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "lynx_team" {
+				continue
+			}
+
+			_, err := server.Client().GetTeam(context.Background(), rs.Primary.ID)
+
+			if !sdk.IsNotFound(err) {
+				return fmt.Errorf("team %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
@@ -10,11 +10,15 @@ import (
 
 	"github.com/clivern/terraform-provider-lynx/sdk"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -22,6 +26,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithConfigValidators = &UserResource{}
 
 func NewUserResource() resource.Resource {
 	return &UserResource{}
@@ -29,16 +34,19 @@ func NewUserResource() resource.Resource {
 
 // UserResource defines the resource implementation.
 type UserResource struct {
-	client *sdk.Client
+	client sdk.LynxAPI
 }
 
 // UserResourceModel describes the resource data model.
 type UserResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	Name     types.String `tfsdk:"name"`
-	Email    types.String `tfsdk:"email"`
-	Role     types.String `tfsdk:"role"`
-	Password types.String `tfsdk:"password"`
+	ID                types.String   `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	Email             types.String   `tfsdk:"email"`
+	Role              types.String   `tfsdk:"role"`
+	Password          types.String   `tfsdk:"password"`
+	PasswordWO        types.String   `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64    `tfsdk:"password_wo_version"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -64,10 +72,25 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Required:            true,
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "User's password",
-				Required:            true,
+				MarkdownDescription: "User's password, it is stored in the state. Use `password_wo` instead",
+				DeprecationMessage:  "The password is stored in the state, use password_wo and password_wo_version instead.",
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "User's password, it is never stored in the plan or state and requires Terraform 1.11 or later. " +
+					"It is only sent on create and when `password_wo_version` changes",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `password_wo`, change it to send a new password",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "User identifier",
@@ -76,6 +99,23 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *UserResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("password"),
+			path.MatchRoot("password_wo"),
+		),
 	}
 }
 
@@ -85,12 +125,12 @@ func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.Client)
+	client, ok := req.ProviderData.(sdk.LynxAPI)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected sdk.LynxAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -109,6 +149,26 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Write-only values are only available in the config
+	var passwordWO types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	newUser := sdk.User{
 		Name:     data.Name.ValueString(),
 		Email:    data.Email.ValueString(),
@@ -116,9 +176,13 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		Password: data.Password.ValueString(),
 	}
 
+	if !passwordWO.IsNull() {
+		newUser.Password = passwordWO.ValueString()
+	}
+
 	tflog.Info(ctx, fmt.Sprintf("Create a user with email %s", newUser.Email))
 
-	createdUser, err := r.client.CreateUser(newUser)
+	createdUser, err := r.client.CreateUser(ctx, newUser)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -150,10 +214,28 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, fmt.Sprintf("Read a user with id %s", data.ID.ValueString()))
 
 	// Retrieve the user using the GetUser method
-	user, err := r.client.GetUser(data.ID.ValueString())
+	user, err := r.client.GetUser(ctx, data.ID.ValueString())
+
+	if sdk.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("User with id %s not found, removing it from state", data.ID.ValueString()))
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -182,6 +264,33 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var state UserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var passwordWO types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Update the user using the UpdateUser method
 	updatedUser := sdk.User{
 		ID:       data.ID.ValueString(),
@@ -191,9 +300,18 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		Password: data.Password.ValueString(),
 	}
 
+	// The write-only password can't be compared with the previous one, so
+	// it is only sent when its version changes or when it replaces the
+	// deprecated password. An empty password keeps the current one
+	changed := !data.PasswordWOVersion.Equal(state.PasswordWOVersion) || !state.Password.IsNull()
+
+	if !passwordWO.IsNull() && changed {
+		updatedUser.Password = passwordWO.ValueString()
+	}
+
 	tflog.Info(ctx, fmt.Sprintf("Update a user with id %s", updatedUser.ID))
 
-	_, err := r.client.UpdateUser(updatedUser)
+	_, err := r.client.UpdateUser(ctx, updatedUser)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -219,11 +337,22 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, fmt.Sprintf("Delete a user with id %s", data.ID.ValueString()))
 
-	err := r.client.DeleteUser(data.ID.ValueString())
+	err := r.client.DeleteUser(ctx, data.ID.ValueString())
 
-	if err != nil {
+	if err != nil && !sdk.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete user, got error: %s", err.Error()),
@@ -0,0 +1,352 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxmock"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxtest"
+
+	"github.com/hashicorp/go-version"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccUserResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckUserDestroy(server),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccUserResourceConfig("Stella", sdk.RegularUser),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("lynx_user.test", "id"),
+					resource.TestCheckResourceAttr("lynx_user.test", "name", "Stella"),
+					resource.TestCheckResourceAttr("lynx_user.test", "email", "stella@example.com"),
+					resource.TestCheckResourceAttr("lynx_user.test", "role", sdk.RegularUser),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "lynx_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The API never returns the password
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccUserResourceConfig("Stella Doe", sdk.SuperUser),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lynx_user.test", "name", "Stella Doe"),
+					resource.TestCheckResourceAttr("lynx_user.test", "role", sdk.SuperUser),
+				),
+			},
+			// Drift detection testing
+			{
+				PreConfig: func() {
+					user := testAccFindUser(t, server, "stella@example.com")
+					user.Name = "Changed Outside"
+
+					if _, err := server.Client().UpdateUser(context.Background(), user); err != nil {
+						t.Fatalf("unable to update user: %s", err)
+					}
+				},
+				Config:             testAccProviderConfig(server) + testAccUserResourceConfig("Stella Doe", sdk.SuperUser),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Deleted outside of Terraform testing
+			{
+				PreConfig: func() {
+					user := testAccFindUser(t, server, "stella@example.com")
+
+					if err := server.Client().DeleteUser(context.Background(), user.ID); err != nil {
+						t.Fatalf("unable to delete user: %s", err)
+					}
+				},
+				Config:             testAccProviderConfig(server) + testAccUserResourceConfig("Stella Doe", sdk.SuperUser),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccUserResourcePasswordWO(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.11.0"))),
+		},
+		CheckDestroy: testAccCheckUserDestroy(server),
+		Steps: []resource.TestStep{
+			// The password is sent but never stored
+			{
+				Config: testAccProviderConfig(server) + testAccUserResourcePasswordWOConfig("~first-password~", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("lynx_user.test", "password"),
+					resource.TestCheckNoResourceAttr("lynx_user.test", "password_wo"),
+					resource.TestCheckResourceAttr("lynx_user.test", "password_wo_version", "1"),
+					testAccCheckUserPassword(server, "~first-password~"),
+				),
+			},
+			// A new password without a new version is ignored
+			{
+				Config: testAccProviderConfig(server) + testAccUserResourcePasswordWOConfig("~second-password~", 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: testAccCheckUserPassword(server, "~first-password~"),
+			},
+			// A new version sends the password
+			{
+				Config: testAccProviderConfig(server) + testAccUserResourcePasswordWOConfig("~second-password~", 2),
+				Check:  testAccCheckUserPassword(server, "~second-password~"),
+			},
+			// Both passwords can't be set
+			{
+				Config: testAccProviderConfig(server) + strings.Replace(
+					testAccUserResourcePasswordWOConfig("~second-password~", 2),
+					`role`,
+					`password = "~password-here~"
+  role`,
+					1,
+				),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			// A valid config again, so the destroy can run
+			{
+				Config: testAccProviderConfig(server) + testAccUserResourcePasswordWOConfig("~second-password~", 2),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestUnitUserResourceReadNotFound(t *testing.T) {
+	client := &lynxmock.Client{
+		GetUserFunc: func(ctx context.Context, userId string) (*sdk.User, error) {
+			return nil, &sdk.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+
+	r, empty := testUnitResource(t, NewUserResource, client)
+	state := testUnitState(t, empty, testUnitUserModel())
+
+	resp := &fwresource.ReadResponse{State: state}
+
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected no error, got: %v", resp.Diagnostics)
+	}
+
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected the user to be removed from state")
+	}
+}
+
+func TestUnitUserResourceCreateError(t *testing.T) {
+	client := &lynxmock.Client{
+		CreateUserFunc: func(ctx context.Context, user sdk.User) (*sdk.User, error) {
+			return nil, &sdk.APIError{StatusCode: http.StatusConflict, Message: "Email is already used"}
+		},
+	}
+
+	r, empty := testUnitResource(t, NewUserResource, client)
+	plan := testUnitState(t, empty, testUnitUserModel())
+
+	resp := &fwresource.CreateResponse{State: empty}
+
+	r.Create(context.Background(), fwresource.CreateRequest{Config: tfsdk.Config(plan), Plan: tfsdk.Plan(plan)}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected no state to be saved")
+	}
+
+	if calls := client.CallsOf("CreateUser"); len(calls) != 1 {
+		t.Errorf("expected 1 CreateUser call, got %d", len(calls))
+	}
+}
+
+func TestUnitUserResourceUpdatePasswordWO(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    func(*UserResourceModel)
+		expected string
+	}{
+		{
+			name:     "same version",
+			state:    func(data *UserResourceModel) {},
+			expected: "",
+		},
+		{
+			name: "new version",
+			state: func(data *UserResourceModel) {
+				data.PasswordWOVersion = types.Int64Value(1)
+			},
+			expected: "~new-password~",
+		},
+		{
+			name: "replaces the deprecated password",
+			state: func(data *UserResourceModel) {
+				data.Password = types.StringValue("~password-here~")
+			},
+			expected: "~new-password~",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated sdk.User
+
+			client := &lynxmock.Client{
+				UpdateUserFunc: func(ctx context.Context, user sdk.User) (*sdk.User, error) {
+					updated = user
+					return &user, nil
+				},
+			}
+
+			r, empty := testUnitResource(t, NewUserResource, client)
+
+			model := testUnitUserModel()
+			model.Password = types.StringNull()
+			model.PasswordWOVersion = types.Int64Value(2)
+
+			// Write-only values are only in the config
+			plan := testUnitState(t, empty, model)
+
+			model.PasswordWO = types.StringValue("~new-password~")
+			config := testUnitState(t, empty, model)
+
+			model.PasswordWO = types.StringNull()
+			model.PasswordWOVersion = types.Int64Value(2)
+			tt.state(&model)
+			state := testUnitState(t, empty, model)
+
+			resp := &fwresource.UpdateResponse{State: empty}
+
+			r.Update(context.Background(), fwresource.UpdateRequest{Config: tfsdk.Config(config), Plan: tfsdk.Plan(plan), State: state}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("expected no error, got: %v", resp.Diagnostics)
+			}
+
+			if updated.Password != tt.expected {
+				t.Errorf("expected password %q, got %q", tt.expected, updated.Password)
+			}
+		})
+	}
+}
+
+// testUnitUserModel returns a user resource model.
+func testUnitUserModel() UserResourceModel {
+	return UserResourceModel{
+		ID:       types.StringValue("3f2e1c0d-0000-4000-8000-000000000001"),
+		Name:     types.StringValue("Stella"),
+		Email:    types.StringValue("stella@example.com"),
+		Role:     types.StringValue(sdk.RegularUser),
+		Password: types.StringValue("~password-here~"),
+		Timeouts: testUnitTimeouts(),
+	}
+}
+
+func testAccUserResourcePasswordWOConfig(password string, version int) string {
+	return fmt.Sprintf(`
+resource "lynx_user" "test" {
+  name                = "Stella"
+  email               = "stella@example.com"
+  role                = "regular"
+  password_wo         = %[1]q
+  password_wo_version = %[2]d
+}
+`, password, version)
+}
+
+// testAccCheckUserPassword checks that the user logs in with the password.
+func testAccCheckUserPassword(server *lynxtest.Server, password string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, err := server.Client().Login(context.Background(), "stella@example.com", password); err != nil {
+			return fmt.Errorf("expected the user to login with %s, got: %s", password, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccUserResourceConfig(name, role string) string {
+	return fmt.Sprintf(`
+resource "lynx_user" "test" {
+  name     = %[1]q
+  email    = "stella@example.com"
+  role     = %[2]q
+  password = "~password-here~"
+}
+`, name, role)
+}
+
+// testAccFindUser finds a user on the fake Lynx API by email.
+func testAccFindUser(t *testing.T, server *lynxtest.Server, email string) sdk.User {
+	users, err := sdk.IterateUsers(server.Client(), sdk.ListOptions{}).All(context.Background())
+
+	if err != nil {
+		t.Fatalf("unable to list users: %s", err)
+	}
+
+	for _, user := range users {
+		if user.Email == email {
+			return user
+		}
+	}
+
+	t.Fatalf("user with email %s not found", email)
+
+	return sdk.User{}
+}
+
+func testAccCheckUserDestroy(server *lynxtest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "lynx_user" {
+				continue
+			}
+
+			_, err := server.Client().GetUser(context.Background(), rs.Primary.ID)
+
+			if !sdk.IsNotFound(err) {
+				return fmt.Errorf("user %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"io"
+)
+
+// LynxAPI - Operations of the Lynx API. Client implements it against a
+// real server, the lynxmock package provides a mock to test consumers
+// without HTTP.
+type LynxAPI interface {
+	GetServerInfo(ctx context.Context) (*ServerInfo, error)
+	Backend(teamSlug, projectSlug, environmentSlug string) (*Backend, error)
+
+	CreateUser(ctx context.Context, user User) (*User, error)
+	UpdateUser(ctx context.Context, user User) (*User, error)
+	GetUser(ctx context.Context, userId string) (*User, error)
+	DeleteUser(ctx context.Context, userId string) error
+	ListUsers(ctx context.Context, opts ListOptions) (*UserList, error)
+
+	CreateTeam(ctx context.Context, team Team) (*Team, error)
+	UpdateTeam(ctx context.Context, team Team) (*Team, error)
+	GetTeam(ctx context.Context, teamId string) (*Team, error)
+	DeleteTeam(ctx context.Context, teamId string) error
+	ListTeams(ctx context.Context, opts ListOptions) (*TeamList, error)
+
+	CreateProject(ctx context.Context, project Project) (*Project, error)
+	UpdateProject(ctx context.Context, project Project) (*Project, error)
+	GetProject(ctx context.Context, projectId string) (*Project, error)
+	DeleteProject(ctx context.Context, projectId string) error
+	ListProjects(ctx context.Context, opts ListOptions) (*ProjectList, error)
+
+	CreateEnvironment(ctx context.Context, environment Environment) (*Environment, error)
+	UpdateEnvironment(ctx context.Context, environment Environment) (*Environment, error)
+	GetEnvironment(ctx context.Context, projectId, environmentId string) (*Environment, error)
+	DeleteEnvironment(ctx context.Context, projectId, environmentId string) error
+	ListEnvironments(ctx context.Context, projectId string, opts ListOptions) (*EnvironmentList, error)
+
+	CreateSnapshot(ctx context.Context, snapshot Snapshot) (*Snapshot, error)
+	GetSnapshot(ctx context.Context, snapshotId string) (*Snapshot, error)
+	DeleteSnapshot(ctx context.Context, snapshotId string) error
+	ListSnapshots(ctx context.Context, opts ListOptions) (*SnapshotList, error)
+	RestoreSnapshot(ctx context.Context, snapshotId string, restore SnapshotRestore) (*Task, error)
+	DownloadSnapshot(ctx context.Context, snapshotId string, w io.Writer) (*SnapshotDownload, error)
+	UploadSnapshot(ctx context.Context, snapshot Snapshot, file string) (*Snapshot, error)
+
+	GetTask(ctx context.Context, taskId string) (*Task, error)
+}
+
+// Ensure Client satisfies the LynxAPI interface.
+var _ LynxAPI = &Client{}
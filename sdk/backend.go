@@ -0,0 +1,65 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// BackendMethod - HTTP method Lynx expects to lock and unlock a state
+const BackendMethod = "POST"
+
+// Backend - Settings of the Terraform HTTP backend storing the state of
+// an environment
+type Backend struct {
+	Address       string
+	LockAddress   string
+	UnlockAddress string
+	LockMethod    string
+	UnlockMethod  string
+}
+
+// BackendConfig - Builds the HTTP backend settings of an environment. The
+// backend is served outside of the API, so the /api/v1 suffix of the API
+// URL is dropped
+func BackendConfig(apiURL, teamSlug, projectSlug, environmentSlug string) (*Backend, error) {
+	base, err := url.Parse(apiURL)
+
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid API URL %s, expected an absolute URL like http://localhost:4000/api/v1", apiURL)
+	}
+
+	for name, slug := range map[string]string{"team": teamSlug, "project": projectSlug, "environment": environmentSlug} {
+		if slug == "" {
+			return nil, fmt.Errorf("the %s slug must not be empty", name)
+		}
+	}
+
+	prefix := fmt.Sprintf(
+		"%s://%s%s/client/%s/%s/%s",
+		base.Scheme,
+		base.Host,
+		strings.TrimSuffix(strings.TrimSuffix(base.Path, "/"), "/api/v1"),
+		url.PathEscape(teamSlug),
+		url.PathEscape(projectSlug),
+		url.PathEscape(environmentSlug),
+	)
+
+	return &Backend{
+		Address:       prefix + "/state",
+		LockAddress:   prefix + "/lock",
+		UnlockAddress: prefix + "/unlock",
+		LockMethod:    BackendMethod,
+		UnlockMethod:  BackendMethod,
+	}, nil
+}
+
+// Backend - Builds the HTTP backend settings of an environment on the
+// client's Lynx instance
+func (c *Client) Backend(teamSlug, projectSlug, environmentSlug string) (*Backend, error) {
+	return BackendConfig(c.ApiURL, teamSlug, projectSlug, environmentSlug)
+}
@@ -5,9 +5,9 @@
 package sdk
 
 import (
-	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -16,44 +16,246 @@ const LocalApiServer = "http://localhost:4000/api/v1"
 
 // Client -
 type Client struct {
-	ApiURL     string
-	ApiKey     string
-	HTTPClient *http.Client
+	ApiURL       string
+	ApiKey       string
+	HTTPClient   *http.Client
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	Headers      map[string]string
+	Credentials  Credentials
+}
+
+// reservedHeaders are set by the client on every request and can't be overridden
+var reservedHeaders = []string{"X-API-Key", "Content-Type"}
+
+// IsReservedHeader - Checks if a header is managed by the client itself
+func IsReservedHeader(name string) bool {
+	for _, header := range reservedHeaders {
+		if http.CanonicalHeaderKey(name) == http.CanonicalHeaderKey(header) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // NewClient -
 func NewClient(apiURL, apiKey string) *Client {
 	client := Client{
-		HTTPClient: &http.Client{Timeout: 10 * time.Second},
-		ApiURL:     apiURL,
-		ApiKey:     apiKey,
+		HTTPClient:   &http.Client{Timeout: 10 * time.Second},
+		ApiURL:       apiURL,
+		ApiKey:       apiKey,
+		MaxRetries:   DefaultMaxRetries,
+		RetryWaitMin: DefaultRetryWaitMin,
+		RetryWaitMax: DefaultRetryWaitMax,
 	}
 
 	return &client
 }
 
+// transport returns the client HTTP transport, it replaces the default
+// transport with a dedicated copy so it can be customized safely
+func (c *Client) transport() *http.Transport {
+	if transport, ok := c.HTTPClient.Transport.(*http.Transport); ok {
+		return transport
+	}
+
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = defaultTransport.Clone()
+	}
+
+	c.HTTPClient.Transport = transport
+
+	return transport
+}
+
+// SetProxy - Sends all the requests through the given proxy instead of
+// the one defined by HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+func (c *Client) SetProxy(proxyURL *url.URL) {
+	c.transport().Proxy = http.ProxyURL(proxyURL)
+}
+
+// doRequest sends the request and returns the response body. The request
+// is bound to its context, so cancellation or an expired deadline aborts
+// the call instead of waiting for the HTTP client timeout. Transient
+// failures are retried up to MaxRetries times. When Credentials are set,
+// a 401 response renews the key once and sends the request again. Every
+// request and response is logged to the lynx_sdk subsystem with secrets masked.
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	_, body, err := c.send(req, false)
 
-	req.Header.Set("X-API-Key", c.ApiKey)
-	req.Header.Set("Content-Type", "application/json")
+	return body, err
+}
 
-	res, err := c.HTTPClient.Do(req)
+// doStream sends the request like doRequest but returns the successful
+// response with its body unread, so large payloads can be streamed. The
+// request timeout doesn't apply, the context bounds the transfer. The
+// caller must close the body
+func (c *Client) doStream(req *http.Request) (*http.Response, error) {
+	res, _, err := c.send(req, true)
 
-	if err != nil {
-		return nil, err
+	return res, err
+}
+
+// send sends the request with retries. Error responses are always read,
+// successful ones are only read unless stream is set
+func (c *Client) send(req *http.Request, stream bool) (*http.Response, []byte, error) {
+
+	for name, value := range c.Headers {
+		if !IsReservedHeader(name) {
+			req.Header.Set(name, value)
+		}
 	}
 
-	defer res.Body.Close()
+	apiKey := c.ApiKey
+
+	if c.Credentials != nil {
+		key, err := c.Credentials.APIKey(req.Context())
 
-	body, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			closeBody(req)
+			return nil, nil, err
+		}
 
-	if err != nil {
-		return nil, err
+		apiKey = key
 	}
 
-	if res.StatusCode >= http.StatusBadRequest {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+	req.Header.Set("X-API-Key", apiKey)
+
+	// Uploads set their own multipart content type
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
-	return body, err
+	ctx := newLogContext(req.Context(), apiKey)
+
+	httpClient := c.HTTPClient
+
+	// A streamed transfer can outlast a single request timeout
+	if stream && httpClient.Timeout > 0 {
+		transferClient := *httpClient
+		transferClient.Timeout = 0
+		httpClient = &transferClient
+	}
+
+	refreshed := false
+
+	for attempt, sent := 0, 0; ; attempt, sent = attempt+1, sent+1 {
+		if sent > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+
+			if err != nil {
+				closeBody(req)
+				return nil, nil, err
+			}
+
+			req.Body = body
+		}
+
+		logRequest(ctx, req, attempt)
+
+		start := time.Now()
+
+		res, err := httpClient.Do(req)
+
+		if err != nil {
+			logError(ctx, req, err, time.Since(start).Milliseconds())
+
+			// Surface the context error as is, so callers can match it
+			// with errors.Is(err, context.Canceled) for example.
+			if ctxErr := req.Context().Err(); ctxErr != nil {
+				return nil, nil, ctxErr
+			}
+
+			if attempt < c.MaxRetries && shouldRetryError(req.Method, err) {
+				if err := c.wait(req, attempt, nil); err != nil {
+					return nil, nil, err
+				}
+
+				continue
+			}
+
+			return nil, nil, err
+		}
+
+		if stream && res.StatusCode < http.StatusBadRequest {
+			logResponse(ctx, req, res, nil, time.Since(start).Milliseconds())
+
+			return res, nil, nil
+		}
+
+		body, err := io.ReadAll(res.Body)
+
+		res.Body.Close()
+
+		if err != nil {
+			logError(ctx, req, err, time.Since(start).Milliseconds())
+
+			if ctxErr := req.Context().Err(); ctxErr != nil {
+				return nil, nil, ctxErr
+			}
+
+			return nil, nil, err
+		}
+
+		logResponse(ctx, req, res, body, time.Since(start).Milliseconds())
+
+		if res.StatusCode == http.StatusUnauthorized && c.Credentials != nil && !refreshed {
+			key, err := c.Credentials.Refresh(req.Context(), apiKey)
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			apiKey = key
+			refreshed = true
+			req.Header.Set("X-API-Key", apiKey)
+
+			ctx = newLogContext(req.Context(), apiKey)
+
+			// Renewing the key doesn't count as a retry
+			attempt--
+
+			continue
+		}
+
+		if res.StatusCode >= http.StatusBadRequest {
+			if attempt < c.MaxRetries && shouldRetryStatus(req.Method, res.StatusCode) {
+				if err := c.wait(req, attempt, res); err != nil {
+					return nil, nil, err
+				}
+
+				continue
+			}
+
+			return nil, nil, newAPIError(req, res.StatusCode, body)
+		}
+
+		return res, body, nil
+	}
+}
+
+// closeBody closes the body of a request returned before being sent. The
+// transport closes it otherwise, streamed bodies would leak their source
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+// wait blocks until the next attempt is due or the request context is done
+func (c *Client) wait(req *http.Request, attempt int, res *http.Response) error {
+	timer := time.NewTimer(c.backoff(attempt, res))
+
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
 }
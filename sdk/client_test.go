@@ -0,0 +1,512 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxmock"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxtest"
+)
+
+// TestUnitClientCRUD tests the client against the fake Lynx API
+func TestUnitClientCRUD(t *testing.T) {
+	server := lynxtest.NewServer("secret-key")
+	defer server.Close()
+
+	client := server.Client()
+	ctx := context.Background()
+
+	user, err := client.CreateUser(ctx, sdk.User{Name: "Stella", Email: "stella@example.com", Role: sdk.RegularUser, Password: "password"})
+
+	if err != nil {
+		t.Fatalf("unable to create user: %s", err)
+	}
+
+	team, err := client.CreateTeam(ctx, sdk.Team{Name: "Monitoring", Slug: "monitoring", Members: []string{user.ID}})
+
+	if err != nil {
+		t.Fatalf("unable to create team: %s", err)
+	}
+
+	project, err := client.CreateProject(ctx, sdk.Project{Name: "Grafana", Slug: "grafana", Team: sdk.Team{ID: team.ID}})
+
+	if err != nil {
+		t.Fatalf("unable to create project: %s", err)
+	}
+
+	if project.TeamId != team.ID || project.Team.Slug != "monitoring" {
+		t.Errorf("expected project team %s, got %+v", team.ID, project.Team)
+	}
+
+	environment, err := client.CreateEnvironment(ctx, sdk.Environment{
+		Name: "Development", Slug: "dev", Username: "admin", Secret: "secret", Project: sdk.Project{ID: project.ID},
+	})
+
+	if err != nil {
+		t.Fatalf("unable to create environment: %s", err)
+	}
+
+	environment.Name = "Dev"
+
+	environment, err = client.UpdateEnvironment(ctx, *environment)
+
+	if err != nil || environment.Name != "Dev" {
+		t.Fatalf("unable to update environment: %v %v", environment, err)
+	}
+
+	if _, err := client.CreateTeam(ctx, sdk.Team{Name: "Other", Slug: "monitoring"}); !sdk.IsConflict(err) {
+		t.Errorf("expected a conflict error, got: %v", err)
+	}
+
+	if err := client.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("unable to delete user: %s", err)
+	}
+
+	_, err = client.GetUser(ctx, user.ID)
+
+	var apiErr *sdk.APIError
+
+	if !sdk.IsNotFound(err) || !errors.As(err, &apiErr) || apiErr.Message != "User not found" {
+		t.Errorf("expected a not found error, got: %v", err)
+	}
+}
+
+// TestUnitClientUpdateTeam tests that updates clear the team fields
+func TestUnitClientUpdateTeam(t *testing.T) {
+	server := lynxtest.NewServer("secret-key")
+	defer server.Close()
+
+	// Unsent fields are kept, so the client has to send the cleared ones
+	server.PartialTeamUpdates(true)
+
+	client := server.Client()
+	ctx := context.Background()
+
+	user, err := client.CreateUser(ctx, sdk.User{Name: "Stella", Email: "stella@example.com", Role: sdk.RegularUser, Password: "password"})
+
+	if err != nil {
+		t.Fatalf("unable to create user: %s", err)
+	}
+
+	team, err := client.CreateTeam(ctx, sdk.Team{Name: "Monitoring", Slug: "monitoring", Description: "Monitoring Team", Members: []string{user.ID}})
+
+	if err != nil {
+		t.Fatalf("unable to create team: %s", err)
+	}
+
+	for _, members := range [][]string{nil, {}} {
+		team.Description = ""
+		team.Members = []string{user.ID}
+
+		if _, err := client.UpdateTeam(ctx, *team); err != nil {
+			t.Fatalf("unable to update team: %s", err)
+		}
+
+		team.Members = members
+
+		if _, err := client.UpdateTeam(ctx, *team); err != nil {
+			t.Fatalf("unable to update team: %s", err)
+		}
+
+		current, err := client.GetTeam(ctx, team.ID)
+
+		if err != nil {
+			t.Fatalf("unable to get team: %s", err)
+		}
+
+		if len(current.Members) != 0 || current.Description != "" {
+			t.Errorf("expected the members %v to clear the team, got %+v", members, current)
+		}
+	}
+}
+
+// TestUnitClientRetry tests the retry policy
+func TestUnitClientRetry(t *testing.T) {
+	server := lynxtest.NewServer("secret-key")
+	defer server.Close()
+
+	client := server.Client()
+	ctx := context.Background()
+
+	server.AddFault(lynxtest.Fault{Method: http.MethodGet, Path: "/team", StatusCode: http.StatusServiceUnavailable, Headers: map[string]string{"Retry-After": "0"}, Times: 2})
+
+	if _, err := client.ListTeams(ctx, sdk.ListOptions{}); err != nil {
+		t.Fatalf("expected the request to succeed after retries, got: %s", err)
+	}
+
+	if count := server.CountRequests(http.MethodGet, "/team"); count != 3 {
+		t.Errorf("expected 3 requests, got %d", count)
+	}
+
+	// A 502 may hide a processed request, so a POST must not be retried
+	server.AddFault(lynxtest.Fault{Method: http.MethodPost, Path: "/team", StatusCode: http.StatusBadGateway, Times: 1})
+
+	if _, err := client.CreateTeam(ctx, sdk.Team{Name: "Monitoring", Slug: "monitoring"}); err == nil {
+		t.Fatal("expected the request to fail")
+	}
+
+	if count := server.CountRequests(http.MethodPost, "/team"); count != 1 {
+		t.Errorf("expected 1 request, got %d", count)
+	}
+
+	client.MaxRetries = 0
+
+	server.AddFault(lynxtest.Fault{Method: http.MethodGet, Path: "/team", StatusCode: http.StatusServiceUnavailable, Times: 1})
+
+	if _, err := client.ListTeams(ctx, sdk.ListOptions{}); err == nil {
+		t.Error("expected the request to fail without retries")
+	}
+}
+
+// TestUnitClientHeaders tests that the custom headers are sent and can't
+// override the reserved ones
+func TestUnitClientHeaders(t *testing.T) {
+	server := lynxtest.NewServer("secret-key")
+	defer server.Close()
+
+	client := server.Client()
+	client.Headers = map[string]string{
+		"X-Request-Source": "terraform",
+		"x-api-key":        "override",
+		"CONTENT-TYPE":     "text/plain",
+	}
+
+	if _, err := client.CreateUser(context.Background(), sdk.User{Name: "Stella", Email: "stella@example.com", Role: sdk.RegularUser, Password: "password"}); err != nil {
+		t.Fatalf("unable to create user: %s", err)
+	}
+
+	requests := server.Requests()
+	header := requests[len(requests)-1].Header
+
+	if header.Get("X-Request-Source") != "terraform" {
+		t.Errorf("expected the custom header to reach the server, got: %v", header)
+	}
+
+	if header.Get("X-API-Key") != "secret-key" || header.Get("Content-Type") != "application/json" {
+		t.Errorf("expected the reserved headers to be kept, got: %v", header)
+	}
+}
+
+// TestUnitClientProxy tests that the requests go through the proxy
+func TestUnitClientProxy(t *testing.T) {
+	var proxied []string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+
+		fmt.Fprint(w, `{"version": "proxied"}`)
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	client := sdk.NewClient("http://lynx.example.com/api/v1", "secret-key")
+	client.SetProxy(proxyURL)
+
+	info, err := client.GetServerInfo(context.Background())
+
+	if err != nil || info.Version != "proxied" {
+		t.Fatalf("expected the proxy to answer, got %v: %v", info, err)
+	}
+
+	if len(proxied) != 1 || proxied[0] != "http://lynx.example.com/api/v1/info" {
+		t.Errorf("expected the proxy to receive the API request, got: %v", proxied)
+	}
+}
+
+// TestUnitClientContext tests that a canceled context aborts the request
+func TestUnitClientContext(t *testing.T) {
+	server := lynxtest.NewServer("secret-key")
+	defer server.Close()
+
+	client := server.Client()
+	client.RetryWaitMin = time.Minute
+	client.RetryWaitMax = time.Minute
+
+	server.AddFault(lynxtest.Fault{StatusCode: http.StatusServiceUnavailable})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetTeam(ctx, "x"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline exceeded error, got: %v", err)
+	}
+}
+
+// TestUnitClientIterator tests the list pagination and filters
+func TestUnitClientIterator(t *testing.T) {
+	server := lynxtest.NewServer("secret-key")
+	defer server.Close()
+
+	client := server.Client()
+	ctx := context.Background()
+
+	for _, slug := range []string{"aa", "bb", "cc", "dd", "ee"} {
+		if _, err := client.CreateTeam(ctx, sdk.Team{Name: slug, Slug: slug}); err != nil {
+			t.Fatalf("unable to create team: %s", err)
+		}
+	}
+
+	teams, err := sdk.IterateTeams(client, sdk.ListOptions{Limit: 2}).All(ctx)
+
+	if err != nil || len(teams) != 5 {
+		t.Fatalf("expected 5 teams, got %d: %v", len(teams), err)
+	}
+
+	if count := server.CountRequests(http.MethodGet, "/team"); count != 3 {
+		t.Errorf("expected 3 pages, got %d", count)
+	}
+
+	teams, err = sdk.IterateTeams(client, sdk.ListOptions{Limit: 2, Slug: "dd"}).All(ctx)
+
+	if err != nil || len(teams) != 1 || teams[0].Slug != "dd" {
+		t.Errorf("expected team dd, got %v: %v", teams, err)
+	}
+}
+
+// TestUnitClientIteratorTermination tests that the iterator stops with
+// servers missing the total count
+func TestUnitClientIteratorTermination(t *testing.T) {
+	teams := []sdk.Team{{ID: "1", Slug: "aa"}, {ID: "2", Slug: "bb"}}
+
+	for _, test := range []struct {
+		name  string
+		page  func(opts sdk.ListOptions) []sdk.Team
+		calls int
+		err   string
+	}{
+		{
+			name: "empty last page",
+			page: func(opts sdk.ListOptions) []sdk.Team {
+				return teams[min(opts.Offset, len(teams)):]
+			},
+			calls: 2,
+		},
+		{
+			name: "ignored offset",
+			page: func(opts sdk.ListOptions) []sdk.Team {
+				return teams
+			},
+			calls: 2,
+			err:   "the page at offset 2 repeats the previous one",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			client := &lynxmock.Client{
+				ListTeamsFunc: func(ctx context.Context, opts sdk.ListOptions) (*sdk.TeamList, error) {
+					return &sdk.TeamList{Teams: test.page(opts)}, nil
+				},
+			}
+
+			items, err := sdk.IterateTeams(client, sdk.ListOptions{Limit: 2}).All(context.Background())
+
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("expected an error containing %q, got: %v", test.err, err)
+				}
+			} else if err != nil || len(items) != len(teams) {
+				t.Errorf("expected %d teams, got %d: %v", len(teams), len(items), err)
+			}
+
+			if calls := len(client.CallsOf("ListTeams")); calls != test.calls {
+				t.Errorf("expected %d pages, got %d", test.calls, calls)
+			}
+		})
+	}
+}
+
+// TestUnitClientDownloadSnapshot tests the snapshot download and its checksum
+func TestUnitClientDownloadSnapshot(t *testing.T) {
+	server := lynxtest.NewServer("secret-key")
+	defer server.Close()
+
+	client := server.Client()
+	ctx := context.Background()
+
+	team, err := client.CreateTeam(ctx, sdk.Team{Name: "Monitoring", Slug: "monitoring"})
+
+	if err != nil {
+		t.Fatalf("unable to create team: %s", err)
+	}
+
+	project, err := client.CreateProject(ctx, sdk.Project{Name: "Grafana", Slug: "grafana", Team: sdk.Team{ID: team.ID}})
+
+	if err != nil {
+		t.Fatalf("unable to create project: %s", err)
+	}
+
+	snapshot, err := client.CreateSnapshot(ctx, sdk.Snapshot{Title: "Grafana", RecordType: sdk.ProjectRecord, RecordID: project.ID, Team: sdk.Team{ID: team.ID}})
+
+	if err != nil {
+		t.Fatalf("unable to create snapshot: %s", err)
+	}
+
+	if _, err := sdk.WaitForSnapshot(ctx, client, snapshot.ID); err != nil {
+		t.Fatalf("unable to take snapshot: %s", err)
+	}
+
+	var data bytes.Buffer
+
+	download, err := client.DownloadSnapshot(ctx, snapshot.ID, &data)
+
+	if err != nil {
+		t.Fatalf("unable to download snapshot: %s", err)
+	}
+
+	checksum := sha256.Sum256(data.Bytes())
+
+	if download.Size != int64(data.Len()) || download.SHA256 != hex.EncodeToString(checksum[:]) {
+		t.Errorf("expected %d bytes with sha256 %x, got %+v", data.Len(), checksum, download)
+	}
+
+	server.CorruptDownloads(true)
+
+	if _, err := client.DownloadSnapshot(ctx, snapshot.ID, io.Discard); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected a checksum mismatch, got: %v", err)
+	}
+}
+
+// TestUnitClientUploadSnapshot tests the streamed snapshot upload
+func TestUnitClientUploadSnapshot(t *testing.T) {
+	server := lynxtest.NewServer("secret-key")
+	defer server.Close()
+
+	client := server.Client()
+	ctx := context.Background()
+
+	team, err := client.CreateTeam(ctx, sdk.Team{Name: "Monitoring", Slug: "monitoring"})
+
+	if err != nil {
+		t.Fatalf("unable to create team: %s", err)
+	}
+
+	project, err := client.CreateProject(ctx, sdk.Project{Name: "Grafana", Slug: "grafana", Team: sdk.Team{ID: team.ID}})
+
+	if err != nil {
+		t.Fatalf("unable to create project: %s", err)
+	}
+
+	archive := bytes.Repeat([]byte("lynx"), 1<<20)
+	file := filepath.Join(t.TempDir(), "grafana.json")
+
+	if err := os.WriteFile(file, archive, 0o600); err != nil {
+		t.Fatalf("unable to write archive: %s", err)
+	}
+
+	// The retry must send the whole file again
+	server.AddFault(lynxtest.Fault{Method: http.MethodPost, Path: "/snapshot/upload", StatusCode: http.StatusServiceUnavailable, Times: 1})
+
+	// The request timeout doesn't apply to the transfers
+	timeout := client.HTTPClient.Timeout
+	client.HTTPClient.Timeout = time.Nanosecond
+
+	snapshot, err := client.UploadSnapshot(ctx, sdk.Snapshot{Title: "Grafana", RecordType: sdk.ProjectRecord, RecordID: project.ID, Team: sdk.Team{ID: team.ID}}, file)
+
+	client.HTTPClient.Timeout = timeout
+
+	if err != nil {
+		t.Fatalf("unable to upload snapshot: %s", err)
+	}
+
+	if count := server.CountRequests(http.MethodPost, "/snapshot/upload"); count != 2 {
+		t.Errorf("expected 2 requests, got %d", count)
+	}
+
+	if _, err := sdk.WaitForSnapshot(ctx, client, snapshot.ID); err != nil {
+		t.Fatalf("unable to take snapshot: %s", err)
+	}
+
+	download, err := client.DownloadSnapshot(ctx, snapshot.ID, io.Discard)
+
+	checksum := sha256.Sum256(archive)
+
+	if err != nil || download.SHA256 != hex.EncodeToString(checksum[:]) {
+		t.Errorf("expected the uploaded archive back, got %+v: %v", download, err)
+	}
+
+	if _, err := client.UploadSnapshot(ctx, sdk.Snapshot{Title: "Missing"}, filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a missing file error, got: %v", err)
+	}
+
+	// A request that is never sent must release the file and the goroutine
+	// streaming it
+	client.Credentials = failingCredentials{}
+
+	if _, err := client.UploadSnapshot(ctx, sdk.Snapshot{Title: "Grafana"}, file); err == nil || err.Error() != "vault is sealed" {
+		t.Errorf("expected the credentials error, got: %v", err)
+	}
+
+	for deadline := time.Now().Add(time.Second); uploading(); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the upload goroutine to exit")
+		}
+	}
+}
+
+// uploading checks whether a goroutine is still writing a snapshot upload,
+// they run the goroutine started by the UploadSnapshot body opener
+func uploading() bool {
+	stacks := make([]byte, 1<<20)
+
+	return bytes.Contains(stacks[:runtime.Stack(stacks, true)], []byte("sdk.(*Client).UploadSnapshot.func1.1"))
+}
+
+// failingCredentials fails to provide an API key
+type failingCredentials struct{}
+
+func (failingCredentials) APIKey(ctx context.Context) (string, error) {
+	return "", errors.New("vault is sealed")
+}
+
+func (failingCredentials) Refresh(ctx context.Context, rejected string) (string, error) {
+	return "", errors.New("vault is sealed")
+}
+
+// TestUnitClientLogin tests the session login and renewal
+func TestUnitClientLogin(t *testing.T) {
+	server := lynxtest.NewServer("secret-key")
+	defer server.Close()
+
+	client := server.Client()
+	ctx := context.Background()
+
+	if _, err := client.CreateUser(ctx, sdk.User{Name: "Bot", Email: "bot@example.com", Role: sdk.SuperUser, Password: "password"}); err != nil {
+		t.Fatalf("unable to create user: %s", err)
+	}
+
+	client.ApiKey = ""
+	client.Credentials = sdk.NewPasswordCredentials(client, "bot@example.com", "password")
+
+	if _, err := client.ListUsers(ctx, sdk.ListOptions{}); err != nil {
+		t.Fatalf("expected the request to succeed, got: %s", err)
+	}
+
+	server.ExpireSessions()
+
+	if _, err := client.ListUsers(ctx, sdk.ListOptions{}); err != nil {
+		t.Fatalf("expected the session to be renewed, got: %s", err)
+	}
+
+	if count := server.CountRequests(http.MethodPost, "/action/auth"); count != 2 {
+		t.Errorf("expected 2 logins, got %d", count)
+	}
+}
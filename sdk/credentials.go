@@ -0,0 +1,231 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CommandExpiryWindow - How long before its expiry a key from a credential
+// command gets renewed
+const CommandExpiryWindow = time.Minute
+
+// Credentials - Provides the key sent as X-API-Key with every request
+type Credentials interface {
+	// APIKey returns the current key
+	APIKey(ctx context.Context) (string, error)
+
+	// Refresh renews the key once the API rejected it with a 401
+	Refresh(ctx context.Context, rejected string) (string, error)
+}
+
+// Session Model
+type Session struct {
+	Token  string `json:"token"`
+	UserID string `json:"user"`
+}
+
+// Login - Exchanges a user's email and password for a session token
+func (c *Client) Login(ctx context.Context, email, password string) (*Session, error) {
+
+	rb, err := json.Marshal(map[string]string{
+		"email":    email,
+		"password": password,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"POST",
+		fmt.Sprintf("%s/action/auth", c.ApiURL),
+		strings.NewReader(string(rb)),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Login is not authenticated, so use a copy of the client without
+	// credentials to avoid looping back into them
+	anonymous := *c
+	anonymous.ApiKey = ""
+	anonymous.Credentials = nil
+
+	body, err := anonymous.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	session := Session{}
+
+	err = json.Unmarshal(body, &session)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Token == "" {
+		return nil, fmt.Errorf("login response has no token")
+	}
+
+	return &session, nil
+}
+
+// PasswordCredentials - Logs in with an email and a password and shares the
+// session token between all the requests
+type PasswordCredentials struct {
+	client   *Client
+	email    string
+	password string
+	token    string
+	mutex    sync.Mutex
+}
+
+// NewPasswordCredentials creates a new PasswordCredentials instance
+func NewPasswordCredentials(client *Client, email, password string) *PasswordCredentials {
+	return &PasswordCredentials{
+		client:   client,
+		email:    email,
+		password: password,
+	}
+}
+
+// APIKey returns the session token, it logs in on the first call
+func (p *PasswordCredentials) APIKey(ctx context.Context) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.token != "" {
+		return p.token, nil
+	}
+
+	return p.login(ctx)
+}
+
+// Refresh logs in again unless another request already renewed the token
+func (p *PasswordCredentials) Refresh(ctx context.Context, rejected string) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.token != "" && p.token != rejected {
+		return p.token, nil
+	}
+
+	return p.login(ctx)
+}
+
+// login fetches a new session token
+func (p *PasswordCredentials) login(ctx context.Context) (string, error) {
+	session, err := p.client.Login(ctx, p.email, p.password)
+
+	if err != nil {
+		return "", fmt.Errorf("unable to login with email %s: %w", p.email, err)
+	}
+
+	p.token = session.Token
+
+	return p.token, nil
+}
+
+// commandOutput - JSON printed by a credential command
+type commandOutput struct {
+	APIKey    string     `json:"api_key"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CommandCredentials - Runs an external command that prints the key as
+// JSON like {"api_key": "~api-key-here~", "expires_at": "2024-01-01T00:00:00Z"}
+// and caches the key until it expires
+type CommandCredentials struct {
+	command   string
+	key       string
+	expiresAt time.Time
+	mutex     sync.Mutex
+}
+
+// NewCommandCredentials creates a new CommandCredentials instance
+func NewCommandCredentials(command string) *CommandCredentials {
+	return &CommandCredentials{
+		command: command,
+	}
+}
+
+// APIKey returns the cached key, it runs the command on the first call
+// and once the key expires
+func (c *CommandCredentials) APIKey(ctx context.Context) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.key != "" && (c.expiresAt.IsZero() || time.Now().Add(CommandExpiryWindow).Before(c.expiresAt)) {
+		return c.key, nil
+	}
+
+	return c.run(ctx)
+}
+
+// Refresh runs the command again unless another request already renewed the key
+func (c *CommandCredentials) Refresh(ctx context.Context, rejected string) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.key != "" && c.key != rejected {
+		return c.key, nil
+	}
+
+	return c.run(ctx)
+}
+
+// run executes the command with the system shell and parses its output
+func (c *CommandCredentials) run(ctx context.Context) (string, error) {
+	var cmd *exec.Cmd
+
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", c.command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", c.command)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("credential command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	output := commandOutput{}
+
+	// The output holds the key, so it is never part of the error
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return "", fmt.Errorf("credential command printed invalid JSON")
+	}
+
+	if output.APIKey == "" {
+		return "", fmt.Errorf("credential command printed no api_key")
+	}
+
+	c.key = output.APIKey
+	c.expiresAt = time.Time{}
+
+	if output.ExpiresAt != nil {
+		c.expiresAt = *output.ExpiresAt
+	}
+
+	return c.key, nil
+}
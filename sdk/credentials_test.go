@@ -0,0 +1,64 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxtest"
+)
+
+// TestUnitCommandCredentials tests fetching the API key with a command
+func TestUnitCommandCredentials(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the credential command is a shell script")
+	}
+
+	server := lynxtest.NewServer("secret-key")
+	defer server.Close()
+
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "counter")
+
+	// The first run prints a stale key, the next ones print the valid key
+	command := strings.Join([]string{
+		"count=$(cat " + counter + " 2>/dev/null || echo 0)",
+		"count=$((count + 1))",
+		"echo $count > " + counter,
+		`if [ $count -eq 1 ]; then echo '{"api_key": "stale-key"}'; else echo '{"api_key": "secret-key", "expires_at": "2999-01-01T00:00:00Z"}'; fi`,
+	}, "; ")
+
+	client := server.Client()
+	client.ApiKey = ""
+	client.Credentials = sdk.NewCommandCredentials(command)
+
+	ctx := context.Background()
+
+	if _, err := client.CreateUser(ctx, sdk.User{Name: "Stella", Email: "stella@example.com", Role: sdk.RegularUser, Password: "password"}); err != nil {
+		t.Fatalf("expected the rejected key to be refreshed, got: %s", err)
+	}
+
+	if _, err := client.ListUsers(ctx, sdk.ListOptions{}); err != nil {
+		t.Fatalf("unable to list users: %s", err)
+	}
+
+	content, _ := os.ReadFile(counter)
+
+	if strings.TrimSpace(string(content)) != "2" {
+		t.Errorf("expected the command to run twice, got %s", content)
+	}
+
+	failing := sdk.NewCommandCredentials("echo 'vault is sealed' >&2; exit 1")
+
+	if _, err := failing.APIKey(ctx); err == nil || !strings.Contains(err.Error(), "vault is sealed") {
+		t.Errorf("expected the command error output, got: %v", err)
+	}
+}
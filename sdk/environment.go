@@ -5,6 +5,7 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -12,14 +13,15 @@ import (
 )
 
 // CreateEnvironment - Creates a new Environment
-func (c *Client) CreateEnvironment(environment Environment) (*Environment, error) {
+func (c *Client) CreateEnvironment(ctx context.Context, environment Environment) (*Environment, error) {
 	rb, err := json.Marshal(environment)
 
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"POST",
 		fmt.Sprintf("%s/project/%s/environment", c.ApiURL, environment.Project.ID),
 		strings.NewReader(string(rb)),
@@ -47,14 +49,15 @@ func (c *Client) CreateEnvironment(environment Environment) (*Environment, error
 }
 
 // UpdateEnvironment - Updates an Environment
-func (c *Client) UpdateEnvironment(environment Environment) (*Environment, error) {
+func (c *Client) UpdateEnvironment(ctx context.Context, environment Environment) (*Environment, error) {
 	rb, err := json.Marshal(environment)
 
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"PUT",
 		fmt.Sprintf("%s/project/%s/environment/%s", c.ApiURL, environment.Project.ID, environment.ID),
 		strings.NewReader(string(rb)),
@@ -82,9 +85,10 @@ func (c *Client) UpdateEnvironment(environment Environment) (*Environment, error
 }
 
 // GetEnvironment - Gets a new Environment
-func (c *Client) GetEnvironment(projectId, environmentId string) (*Environment, error) {
+func (c *Client) GetEnvironment(ctx context.Context, projectId, environmentId string) (*Environment, error) {
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("%s/project/%s/environment/%s", c.ApiURL, projectId, environmentId),
 		nil,
@@ -112,9 +116,10 @@ func (c *Client) GetEnvironment(projectId, environmentId string) (*Environment,
 }
 
 // DeleteEnvironment - Deletes an Environment
-func (c *Client) DeleteEnvironment(projectId, environmentId string) error {
+func (c *Client) DeleteEnvironment(ctx context.Context, projectId, environmentId string) error {
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"DELETE",
 		fmt.Sprintf("%s/project/%s/environment/%s", c.ApiURL, projectId, environmentId),
 		nil,
@@ -132,3 +137,34 @@ func (c *Client) DeleteEnvironment(projectId, environmentId string) error {
 
 	return nil
 }
+
+// ListEnvironments - Lists the environments of a Project
+func (c *Client) ListEnvironments(ctx context.Context, projectId string, opts ListOptions) (*EnvironmentList, error) {
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s/project/%s/environment?%s", c.ApiURL, projectId, opts.query()),
+		nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list := EnvironmentList{}
+
+	err = json.Unmarshal(body, &list)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError - An error response returned by the Lynx API
+type APIError struct {
+	StatusCode int
+	Message    string
+	Body       string
+	Method     string
+	URL        string
+}
+
+// Error returns the error message
+func (e *APIError) Error() string {
+	message := e.Message
+
+	if message == "" {
+		message = e.Body
+	}
+
+	return fmt.Sprintf("%s %s: status: %d, message: %s", e.Method, e.URL, e.StatusCode, message)
+}
+
+// newAPIError builds an APIError from a failed response. Lynx returns errors
+// as {"errorMessage": "..."}, other fields are checked to support proxies in
+// front of the API.
+func newAPIError(req *http.Request, statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Body:       string(body),
+		Method:     req.Method,
+		URL:        req.URL.Redacted(),
+	}
+
+	payload := struct {
+		ErrorMessage string `json:"errorMessage"`
+		Error        string `json:"error"`
+		Message      string `json:"message"`
+	}{}
+
+	if err := json.Unmarshal(body, &payload); err == nil {
+		switch {
+		case payload.ErrorMessage != "":
+			apiErr.Message = payload.ErrorMessage
+		case payload.Error != "":
+			apiErr.Message = payload.Error
+		case payload.Message != "":
+			apiErr.Message = payload.Message
+		}
+	}
+
+	return apiErr
+}
+
+// hasStatus checks if the error is an APIError with the given status code
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// IsNotFound checks if the error is a 404 response from the API
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict checks if the error is a 409 response from the API
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsUnauthorized checks if the error is a 401 response from the API
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
@@ -0,0 +1,45 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GetServerInfo - Gets the Lynx server information. It is an authenticated
+// and lightweight call, so it is also used to verify the connectivity and
+// the credentials
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s/info", c.ApiURL),
+		nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	info := ServerInfo{}
+
+	err = json.Unmarshal(body, &info)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
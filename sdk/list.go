@@ -0,0 +1,261 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// DefaultListLimit - Default page size of list requests
+const DefaultListLimit = 20
+
+// ListOptions - Pagination and filters of list requests. Filters are sent
+// to the API and also applied on the client side by the iterators, so they
+// work even if the server ignores them.
+type ListOptions struct {
+	Offset     int
+	Limit      int
+	TeamID     string
+	RecordType string
+	Slug       string
+}
+
+// query builds the query string of a list request
+func (o ListOptions) query() string {
+	values := url.Values{}
+
+	limit := o.Limit
+
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+
+	values.Set("offset", strconv.Itoa(o.Offset))
+	values.Set("limit", strconv.Itoa(limit))
+
+	if o.TeamID != "" {
+		values.Set("team_id", o.TeamID)
+	}
+
+	if o.RecordType != "" {
+		values.Set("record_type", o.RecordType)
+	}
+
+	if o.Slug != "" {
+		values.Set("slug", o.Slug)
+	}
+
+	return values.Encode()
+}
+
+// Iterator - Walks through all the pages of a list endpoint. Pages are
+// fetched lazily when Next runs out of items.
+//
+//	it := sdk.IterateUsers(client, sdk.ListOptions{})
+//
+//	for it.Next(ctx) {
+//		user := it.Value()
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	fetch   func(ctx context.Context, opts ListOptions) ([]T, Metadata, error)
+	match   func(item T) bool
+	opts    ListOptions
+	page    []T
+	index   int
+	current T
+	last    bool
+	err     error
+}
+
+// newIterator creates a new iterator
+func newIterator[T any](
+	opts ListOptions,
+	fetch func(ctx context.Context, opts ListOptions) ([]T, Metadata, error),
+	match func(item T) bool,
+) *Iterator[T] {
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultListLimit
+	}
+
+	return &Iterator[T]{
+		fetch: fetch,
+		match: match,
+		opts:  opts,
+	}
+}
+
+// Next advances to the next item matching the filters. It returns false
+// when there are no more items or an error happened.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for it.err == nil {
+		for it.index < len(it.page) {
+			item := it.page[it.index]
+			it.index++
+
+			if it.match(item) {
+				it.current = item
+				return true
+			}
+		}
+
+		if it.last {
+			return false
+		}
+
+		page, metadata, err := it.fetch(ctx, it.opts)
+
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		if len(page) == 0 {
+			return false
+		}
+
+		// Without a total count, a server ignoring the offset would send
+		// the same full page forever
+		if metadata.TotalCount <= 0 && it.opts.Offset > 0 && reflect.DeepEqual(page, it.page) {
+			it.err = fmt.Errorf("the page at offset %d repeats the previous one, the server seems to ignore the offset", it.opts.Offset)
+			return false
+		}
+
+		it.page = page
+		it.index = 0
+		it.opts.Offset += len(page)
+
+		if len(page) < it.opts.Limit || (metadata.TotalCount > 0 && it.opts.Offset >= metadata.TotalCount) {
+			it.last = true
+		}
+	}
+
+	return false
+}
+
+// Value returns the current item
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All collects the remaining items
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	items := []T{}
+
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+	}
+
+	return items, it.Err()
+}
+
+// IterateUsers - Iterates over all users
+func IterateUsers(api LynxAPI, opts ListOptions) *Iterator[User] {
+	return newIterator(
+		opts,
+		func(ctx context.Context, opts ListOptions) ([]User, Metadata, error) {
+			list, err := api.ListUsers(ctx, opts)
+
+			if err != nil {
+				return nil, Metadata{}, err
+			}
+
+			return list.Users, list.Metadata, nil
+		},
+		func(user User) bool {
+			return true
+		},
+	)
+}
+
+// IterateTeams - Iterates over all teams matching the slug filter
+func IterateTeams(api LynxAPI, opts ListOptions) *Iterator[Team] {
+	return newIterator(
+		opts,
+		func(ctx context.Context, opts ListOptions) ([]Team, Metadata, error) {
+			list, err := api.ListTeams(ctx, opts)
+
+			if err != nil {
+				return nil, Metadata{}, err
+			}
+
+			return list.Teams, list.Metadata, nil
+		},
+		func(team Team) bool {
+			return opts.Slug == "" || team.Slug == opts.Slug
+		},
+	)
+}
+
+// IterateProjects - Iterates over all projects matching the team and slug filters
+func IterateProjects(api LynxAPI, opts ListOptions) *Iterator[Project] {
+	return newIterator(
+		opts,
+		func(ctx context.Context, opts ListOptions) ([]Project, Metadata, error) {
+			list, err := api.ListProjects(ctx, opts)
+
+			if err != nil {
+				return nil, Metadata{}, err
+			}
+
+			return list.Projects, list.Metadata, nil
+		},
+		func(project Project) bool {
+			return (opts.Slug == "" || project.Slug == opts.Slug) &&
+				(opts.TeamID == "" || project.Team.ID == opts.TeamID)
+		},
+	)
+}
+
+// IterateEnvironments - Iterates over all environments of a project matching the slug filter
+func IterateEnvironments(api LynxAPI, projectId string, opts ListOptions) *Iterator[Environment] {
+	return newIterator(
+		opts,
+		func(ctx context.Context, opts ListOptions) ([]Environment, Metadata, error) {
+			list, err := api.ListEnvironments(ctx, projectId, opts)
+
+			if err != nil {
+				return nil, Metadata{}, err
+			}
+
+			return list.Environments, list.Metadata, nil
+		},
+		func(environment Environment) bool {
+			return opts.Slug == "" || environment.Slug == opts.Slug
+		},
+	)
+}
+
+// IterateSnapshots - Iterates over all snapshots matching the team and record type filters
+func IterateSnapshots(api LynxAPI, opts ListOptions) *Iterator[Snapshot] {
+	return newIterator(
+		opts,
+		func(ctx context.Context, opts ListOptions) ([]Snapshot, Metadata, error) {
+			list, err := api.ListSnapshots(ctx, opts)
+
+			if err != nil {
+				return nil, Metadata{}, err
+			}
+
+			return list.Snapshots, list.Metadata, nil
+		},
+		func(snapshot Snapshot) bool {
+			return (opts.RecordType == "" || snapshot.RecordType == opts.RecordType) &&
+				(opts.TeamID == "" || snapshot.Team.ID == opts.TeamID)
+		},
+	)
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// LogSubsystem - The tflog subsystem of the sdk requests and responses
+	LogSubsystem = "lynx_sdk"
+
+	// LogLevelEnv - Environment variable to set the sdk subsystem log level
+	LogLevelEnv = "TF_LOG_PROVIDER_LYNX_SDK"
+
+	// maxLoggedBody - Bodies bigger than this are not logged
+	maxLoggedBody = 64 * 1024
+
+	// redacted - Replaces secrets in the logs
+	redacted = "***"
+)
+
+// sensitiveFields are JSON fields masked in the logged payloads
+var sensitiveFields = map[string]bool{
+	"password": true,
+	"username": true,
+	"secret":   true,
+	"token":    true,
+	"api_key":  true,
+	"apiKey":   true,
+}
+
+// sensitiveHeaders are headers masked in the logs
+var sensitiveHeaders = map[string]bool{
+	"X-Api-Key":     true,
+	"Authorization": true,
+	"Cookie":        true,
+}
+
+// newLogContext creates the sdk log subsystem for a request and masks the
+// key sent to the API wherever it shows up
+func newLogContext(ctx context.Context, apiKey string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv(LogLevelEnv))
+
+	if apiKey != "" {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, LogSubsystem, apiKey)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, LogSubsystem, apiKey)
+	}
+
+	return ctx
+}
+
+// logRequest logs the request method, URL, headers and payload
+func logRequest(ctx context.Context, req *http.Request, attempt int) {
+	fields := map[string]interface{}{
+		"method":  req.Method,
+		"url":     req.URL.Redacted(),
+		"attempt": attempt + 1,
+		"headers": redactHeaders(req.Header),
+	}
+
+	if req.GetBody != nil && req.ContentLength > 0 && req.ContentLength <= maxLoggedBody {
+		if body, err := req.GetBody(); err == nil {
+			content, err := io.ReadAll(body)
+
+			body.Close()
+
+			if err == nil {
+				fields["body"] = redactBody(content)
+			}
+		}
+	}
+
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Sending request to Lynx API", fields)
+}
+
+// logResponse logs the response status, latency and payload
+func logResponse(ctx context.Context, req *http.Request, res *http.Response, body []byte, latency int64) {
+	fields := map[string]interface{}{
+		"method":      req.Method,
+		"url":         req.URL.Redacted(),
+		"status":      res.StatusCode,
+		"duration_ms": latency,
+		"headers":     redactHeaders(res.Header),
+	}
+
+	// Streamed bodies are not read, so there is nothing to log
+	if body != nil && len(body) <= maxLoggedBody {
+		fields["body"] = redactBody(body)
+	}
+
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Received response from Lynx API", fields)
+}
+
+// logError logs a request that failed without a response
+func logError(ctx context.Context, req *http.Request, err error, latency int64) {
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Request to Lynx API failed", map[string]interface{}{
+		"method":      req.Method,
+		"url":         req.URL.Redacted(),
+		"duration_ms": latency,
+		"error":       err.Error(),
+	})
+}
+
+// redactHeaders flattens the headers and masks the sensitive ones
+func redactHeaders(headers http.Header) map[string]string {
+	result := make(map[string]string, len(headers))
+
+	for name, values := range headers {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			result[name] = redacted
+			continue
+		}
+
+		result[name] = strings.Join(values, ", ")
+	}
+
+	return result
+}
+
+// redactBody masks the sensitive fields of a JSON payload. Payloads that
+// are not JSON are logged as they are.
+func redactBody(body []byte) string {
+	var payload interface{}
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return string(body)
+	}
+
+	content, err := json.Marshal(redactValue(payload))
+
+	if err != nil {
+		return string(body)
+	}
+
+	return string(content)
+}
+
+// redactValue walks a decoded JSON value and masks the sensitive fields
+func redactValue(value interface{}) interface{} {
+	switch item := value.(type) {
+	case map[string]interface{}:
+		for key, child := range item {
+			if sensitiveFields[key] {
+				item[key] = redacted
+				continue
+			}
+
+			item[key] = redactValue(child)
+		}
+	case []interface{}:
+		for i, child := range item {
+			item[i] = redactValue(child)
+		}
+	}
+
+	return value
+}
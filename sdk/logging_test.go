@@ -0,0 +1,77 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxtest"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// TestUnitLoggingRedaction tests that the logged requests and responses
+// don't leak the secrets they carry
+func TestUnitLoggingRedaction(t *testing.T) {
+	t.Setenv(sdk.LogLevelEnv, "DEBUG")
+
+	server := lynxtest.NewServer("lynx-api-key-5e2c")
+	defer server.Close()
+
+	var output bytes.Buffer
+
+	client := server.Client()
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	if _, err := client.CreateUser(ctx, sdk.User{Name: "Stella", Email: "stella@example.com", Role: sdk.RegularUser, Password: "stella-password-91f3"}); err != nil {
+		t.Fatalf("unable to create user: %s", err)
+	}
+
+	session, err := client.Login(ctx, "stella@example.com", "stella-password-91f3")
+
+	if err != nil {
+		t.Fatalf("unable to login: %s", err)
+	}
+
+	client.ApiKey = session.Token
+
+	if _, err := client.ListUsers(ctx, sdk.ListOptions{}); err != nil {
+		t.Fatalf("unable to list users: %s", err)
+	}
+
+	logs := output.String()
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+
+	if err != nil {
+		t.Fatalf("unable to decode logs: %s", err)
+	}
+
+	if len(entries) != 6 {
+		t.Fatalf("expected a request and a response logged per call, got %d entries", len(entries))
+	}
+
+	for _, entry := range entries {
+		if entry["@module"] != "provider."+sdk.LogSubsystem {
+			t.Errorf("expected entries of the %s subsystem, got: %v", sdk.LogSubsystem, entry["@module"])
+		}
+	}
+
+	for _, secret := range []string{"lynx-api-key-5e2c", "stella-password-91f3", session.Token} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("expected %q to be redacted from the logs", secret)
+		}
+	}
+
+	for _, value := range []string{"stella@example.com", "Stella", "/api/v1/action/auth", "/api/v1/user", "X-Api-Key", "POST", "GET"} {
+		if !strings.Contains(logs, value) {
+			t.Errorf("expected %q to be logged", value)
+		}
+	}
+}
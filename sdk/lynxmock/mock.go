@@ -0,0 +1,434 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package lynxmock provides a hand written mock of the sdk.LynxAPI
+// interface to unit test code without a Lynx server.
+package lynxmock
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+)
+
+// Ensure Client satisfies the sdk.LynxAPI interface.
+var _ sdk.LynxAPI = &Client{}
+
+// Call - A recorded call to the mock
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Client - Mock of sdk.LynxAPI. Each method calls the matching function
+// field, methods without a function return an error.
+//
+//	client := &lynxmock.Client{
+//		GetUserFunc: func(ctx context.Context, userId string) (*sdk.User, error) {
+//			return nil, &sdk.APIError{StatusCode: http.StatusNotFound}
+//		},
+//	}
+type Client struct {
+	GetServerInfoFunc func(ctx context.Context) (*sdk.ServerInfo, error)
+	BackendFunc       func(teamSlug, projectSlug, environmentSlug string) (*sdk.Backend, error)
+
+	CreateUserFunc func(ctx context.Context, user sdk.User) (*sdk.User, error)
+	UpdateUserFunc func(ctx context.Context, user sdk.User) (*sdk.User, error)
+	GetUserFunc    func(ctx context.Context, userId string) (*sdk.User, error)
+	DeleteUserFunc func(ctx context.Context, userId string) error
+	ListUsersFunc  func(ctx context.Context, opts sdk.ListOptions) (*sdk.UserList, error)
+
+	CreateTeamFunc func(ctx context.Context, team sdk.Team) (*sdk.Team, error)
+	UpdateTeamFunc func(ctx context.Context, team sdk.Team) (*sdk.Team, error)
+	GetTeamFunc    func(ctx context.Context, teamId string) (*sdk.Team, error)
+	DeleteTeamFunc func(ctx context.Context, teamId string) error
+	ListTeamsFunc  func(ctx context.Context, opts sdk.ListOptions) (*sdk.TeamList, error)
+
+	CreateProjectFunc func(ctx context.Context, project sdk.Project) (*sdk.Project, error)
+	UpdateProjectFunc func(ctx context.Context, project sdk.Project) (*sdk.Project, error)
+	GetProjectFunc    func(ctx context.Context, projectId string) (*sdk.Project, error)
+	DeleteProjectFunc func(ctx context.Context, projectId string) error
+	ListProjectsFunc  func(ctx context.Context, opts sdk.ListOptions) (*sdk.ProjectList, error)
+
+	CreateEnvironmentFunc func(ctx context.Context, environment sdk.Environment) (*sdk.Environment, error)
+	UpdateEnvironmentFunc func(ctx context.Context, environment sdk.Environment) (*sdk.Environment, error)
+	GetEnvironmentFunc    func(ctx context.Context, projectId, environmentId string) (*sdk.Environment, error)
+	DeleteEnvironmentFunc func(ctx context.Context, projectId, environmentId string) error
+	ListEnvironmentsFunc  func(ctx context.Context, projectId string, opts sdk.ListOptions) (*sdk.EnvironmentList, error)
+
+	CreateSnapshotFunc   func(ctx context.Context, snapshot sdk.Snapshot) (*sdk.Snapshot, error)
+	GetSnapshotFunc      func(ctx context.Context, snapshotId string) (*sdk.Snapshot, error)
+	DeleteSnapshotFunc   func(ctx context.Context, snapshotId string) error
+	ListSnapshotsFunc    func(ctx context.Context, opts sdk.ListOptions) (*sdk.SnapshotList, error)
+	RestoreSnapshotFunc  func(ctx context.Context, snapshotId string, restore sdk.SnapshotRestore) (*sdk.Task, error)
+	DownloadSnapshotFunc func(ctx context.Context, snapshotId string, w io.Writer) (*sdk.SnapshotDownload, error)
+	UploadSnapshotFunc   func(ctx context.Context, snapshot sdk.Snapshot, file string) (*sdk.Snapshot, error)
+
+	GetTaskFunc func(ctx context.Context, taskId string) (*sdk.Task, error)
+
+	mutex sync.Mutex
+	calls []Call
+}
+
+// Calls returns the recorded calls
+func (c *Client) Calls() []Call {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]Call{}, c.calls...)
+}
+
+// CallsOf returns the recorded calls of a method
+func (c *Client) CallsOf(method string) []Call {
+	calls := []Call{}
+
+	for _, call := range c.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// record saves a call
+func (c *Client) record(method string, args ...interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.calls = append(c.calls, Call{Method: method, Args: args})
+}
+
+// GetServerInfo calls GetServerInfoFunc
+func (c *Client) GetServerInfo(ctx context.Context) (*sdk.ServerInfo, error) {
+	c.record("GetServerInfo")
+
+	if c.GetServerInfoFunc == nil {
+		return nil, fmt.Errorf("lynxmock: GetServerInfo is not implemented")
+	}
+
+	return c.GetServerInfoFunc(ctx)
+}
+
+// Backend calls BackendFunc
+func (c *Client) Backend(teamSlug, projectSlug, environmentSlug string) (*sdk.Backend, error) {
+	c.record("Backend", teamSlug, projectSlug, environmentSlug)
+
+	if c.BackendFunc == nil {
+		return nil, fmt.Errorf("lynxmock: Backend is not implemented")
+	}
+
+	return c.BackendFunc(teamSlug, projectSlug, environmentSlug)
+}
+
+// CreateUser calls CreateUserFunc
+func (c *Client) CreateUser(ctx context.Context, user sdk.User) (*sdk.User, error) {
+	c.record("CreateUser", user)
+
+	if c.CreateUserFunc == nil {
+		return nil, fmt.Errorf("lynxmock: CreateUser is not implemented")
+	}
+
+	return c.CreateUserFunc(ctx, user)
+}
+
+// UpdateUser calls UpdateUserFunc
+func (c *Client) UpdateUser(ctx context.Context, user sdk.User) (*sdk.User, error) {
+	c.record("UpdateUser", user)
+
+	if c.UpdateUserFunc == nil {
+		return nil, fmt.Errorf("lynxmock: UpdateUser is not implemented")
+	}
+
+	return c.UpdateUserFunc(ctx, user)
+}
+
+// GetUser calls GetUserFunc
+func (c *Client) GetUser(ctx context.Context, userId string) (*sdk.User, error) {
+	c.record("GetUser", userId)
+
+	if c.GetUserFunc == nil {
+		return nil, fmt.Errorf("lynxmock: GetUser is not implemented")
+	}
+
+	return c.GetUserFunc(ctx, userId)
+}
+
+// DeleteUser calls DeleteUserFunc
+func (c *Client) DeleteUser(ctx context.Context, userId string) error {
+	c.record("DeleteUser", userId)
+
+	if c.DeleteUserFunc == nil {
+		return fmt.Errorf("lynxmock: DeleteUser is not implemented")
+	}
+
+	return c.DeleteUserFunc(ctx, userId)
+}
+
+// ListUsers calls ListUsersFunc
+func (c *Client) ListUsers(ctx context.Context, opts sdk.ListOptions) (*sdk.UserList, error) {
+	c.record("ListUsers", opts)
+
+	if c.ListUsersFunc == nil {
+		return nil, fmt.Errorf("lynxmock: ListUsers is not implemented")
+	}
+
+	return c.ListUsersFunc(ctx, opts)
+}
+
+// CreateTeam calls CreateTeamFunc
+func (c *Client) CreateTeam(ctx context.Context, team sdk.Team) (*sdk.Team, error) {
+	c.record("CreateTeam", team)
+
+	if c.CreateTeamFunc == nil {
+		return nil, fmt.Errorf("lynxmock: CreateTeam is not implemented")
+	}
+
+	return c.CreateTeamFunc(ctx, team)
+}
+
+// UpdateTeam calls UpdateTeamFunc
+func (c *Client) UpdateTeam(ctx context.Context, team sdk.Team) (*sdk.Team, error) {
+	c.record("UpdateTeam", team)
+
+	if c.UpdateTeamFunc == nil {
+		return nil, fmt.Errorf("lynxmock: UpdateTeam is not implemented")
+	}
+
+	return c.UpdateTeamFunc(ctx, team)
+}
+
+// GetTeam calls GetTeamFunc
+func (c *Client) GetTeam(ctx context.Context, teamId string) (*sdk.Team, error) {
+	c.record("GetTeam", teamId)
+
+	if c.GetTeamFunc == nil {
+		return nil, fmt.Errorf("lynxmock: GetTeam is not implemented")
+	}
+
+	return c.GetTeamFunc(ctx, teamId)
+}
+
+// DeleteTeam calls DeleteTeamFunc
+func (c *Client) DeleteTeam(ctx context.Context, teamId string) error {
+	c.record("DeleteTeam", teamId)
+
+	if c.DeleteTeamFunc == nil {
+		return fmt.Errorf("lynxmock: DeleteTeam is not implemented")
+	}
+
+	return c.DeleteTeamFunc(ctx, teamId)
+}
+
+// ListTeams calls ListTeamsFunc
+func (c *Client) ListTeams(ctx context.Context, opts sdk.ListOptions) (*sdk.TeamList, error) {
+	c.record("ListTeams", opts)
+
+	if c.ListTeamsFunc == nil {
+		return nil, fmt.Errorf("lynxmock: ListTeams is not implemented")
+	}
+
+	return c.ListTeamsFunc(ctx, opts)
+}
+
+// CreateProject calls CreateProjectFunc
+func (c *Client) CreateProject(ctx context.Context, project sdk.Project) (*sdk.Project, error) {
+	c.record("CreateProject", project)
+
+	if c.CreateProjectFunc == nil {
+		return nil, fmt.Errorf("lynxmock: CreateProject is not implemented")
+	}
+
+	return c.CreateProjectFunc(ctx, project)
+}
+
+// UpdateProject calls UpdateProjectFunc
+func (c *Client) UpdateProject(ctx context.Context, project sdk.Project) (*sdk.Project, error) {
+	c.record("UpdateProject", project)
+
+	if c.UpdateProjectFunc == nil {
+		return nil, fmt.Errorf("lynxmock: UpdateProject is not implemented")
+	}
+
+	return c.UpdateProjectFunc(ctx, project)
+}
+
+// GetProject calls GetProjectFunc
+func (c *Client) GetProject(ctx context.Context, projectId string) (*sdk.Project, error) {
+	c.record("GetProject", projectId)
+
+	if c.GetProjectFunc == nil {
+		return nil, fmt.Errorf("lynxmock: GetProject is not implemented")
+	}
+
+	return c.GetProjectFunc(ctx, projectId)
+}
+
+// DeleteProject calls DeleteProjectFunc
+func (c *Client) DeleteProject(ctx context.Context, projectId string) error {
+	c.record("DeleteProject", projectId)
+
+	if c.DeleteProjectFunc == nil {
+		return fmt.Errorf("lynxmock: DeleteProject is not implemented")
+	}
+
+	return c.DeleteProjectFunc(ctx, projectId)
+}
+
+// ListProjects calls ListProjectsFunc
+func (c *Client) ListProjects(ctx context.Context, opts sdk.ListOptions) (*sdk.ProjectList, error) {
+	c.record("ListProjects", opts)
+
+	if c.ListProjectsFunc == nil {
+		return nil, fmt.Errorf("lynxmock: ListProjects is not implemented")
+	}
+
+	return c.ListProjectsFunc(ctx, opts)
+}
+
+// CreateEnvironment calls CreateEnvironmentFunc
+func (c *Client) CreateEnvironment(ctx context.Context, environment sdk.Environment) (*sdk.Environment, error) {
+	c.record("CreateEnvironment", environment)
+
+	if c.CreateEnvironmentFunc == nil {
+		return nil, fmt.Errorf("lynxmock: CreateEnvironment is not implemented")
+	}
+
+	return c.CreateEnvironmentFunc(ctx, environment)
+}
+
+// UpdateEnvironment calls UpdateEnvironmentFunc
+func (c *Client) UpdateEnvironment(ctx context.Context, environment sdk.Environment) (*sdk.Environment, error) {
+	c.record("UpdateEnvironment", environment)
+
+	if c.UpdateEnvironmentFunc == nil {
+		return nil, fmt.Errorf("lynxmock: UpdateEnvironment is not implemented")
+	}
+
+	return c.UpdateEnvironmentFunc(ctx, environment)
+}
+
+// GetEnvironment calls GetEnvironmentFunc
+func (c *Client) GetEnvironment(ctx context.Context, projectId, environmentId string) (*sdk.Environment, error) {
+	c.record("GetEnvironment", projectId, environmentId)
+
+	if c.GetEnvironmentFunc == nil {
+		return nil, fmt.Errorf("lynxmock: GetEnvironment is not implemented")
+	}
+
+	return c.GetEnvironmentFunc(ctx, projectId, environmentId)
+}
+
+// DeleteEnvironment calls DeleteEnvironmentFunc
+func (c *Client) DeleteEnvironment(ctx context.Context, projectId, environmentId string) error {
+	c.record("DeleteEnvironment", projectId, environmentId)
+
+	if c.DeleteEnvironmentFunc == nil {
+		return fmt.Errorf("lynxmock: DeleteEnvironment is not implemented")
+	}
+
+	return c.DeleteEnvironmentFunc(ctx, projectId, environmentId)
+}
+
+// ListEnvironments calls ListEnvironmentsFunc
+func (c *Client) ListEnvironments(ctx context.Context, projectId string, opts sdk.ListOptions) (*sdk.EnvironmentList, error) {
+	c.record("ListEnvironments", projectId, opts)
+
+	if c.ListEnvironmentsFunc == nil {
+		return nil, fmt.Errorf("lynxmock: ListEnvironments is not implemented")
+	}
+
+	return c.ListEnvironmentsFunc(ctx, projectId, opts)
+}
+
+// CreateSnapshot calls CreateSnapshotFunc
+func (c *Client) CreateSnapshot(ctx context.Context, snapshot sdk.Snapshot) (*sdk.Snapshot, error) {
+	c.record("CreateSnapshot", snapshot)
+
+	if c.CreateSnapshotFunc == nil {
+		return nil, fmt.Errorf("lynxmock: CreateSnapshot is not implemented")
+	}
+
+	return c.CreateSnapshotFunc(ctx, snapshot)
+}
+
+// GetSnapshot calls GetSnapshotFunc
+func (c *Client) GetSnapshot(ctx context.Context, snapshotId string) (*sdk.Snapshot, error) {
+	c.record("GetSnapshot", snapshotId)
+
+	if c.GetSnapshotFunc == nil {
+		return nil, fmt.Errorf("lynxmock: GetSnapshot is not implemented")
+	}
+
+	return c.GetSnapshotFunc(ctx, snapshotId)
+}
+
+// DeleteSnapshot calls DeleteSnapshotFunc
+func (c *Client) DeleteSnapshot(ctx context.Context, snapshotId string) error {
+	c.record("DeleteSnapshot", snapshotId)
+
+	if c.DeleteSnapshotFunc == nil {
+		return fmt.Errorf("lynxmock: DeleteSnapshot is not implemented")
+	}
+
+	return c.DeleteSnapshotFunc(ctx, snapshotId)
+}
+
+// ListSnapshots calls ListSnapshotsFunc
+func (c *Client) ListSnapshots(ctx context.Context, opts sdk.ListOptions) (*sdk.SnapshotList, error) {
+	c.record("ListSnapshots", opts)
+
+	if c.ListSnapshotsFunc == nil {
+		return nil, fmt.Errorf("lynxmock: ListSnapshots is not implemented")
+	}
+
+	return c.ListSnapshotsFunc(ctx, opts)
+}
+
+// RestoreSnapshot calls RestoreSnapshotFunc
+func (c *Client) RestoreSnapshot(ctx context.Context, snapshotId string, restore sdk.SnapshotRestore) (*sdk.Task, error) {
+	c.record("RestoreSnapshot", snapshotId, restore)
+
+	if c.RestoreSnapshotFunc == nil {
+		return nil, fmt.Errorf("lynxmock: RestoreSnapshot is not implemented")
+	}
+
+	return c.RestoreSnapshotFunc(ctx, snapshotId, restore)
+}
+
+// DownloadSnapshot calls DownloadSnapshotFunc
+func (c *Client) DownloadSnapshot(ctx context.Context, snapshotId string, w io.Writer) (*sdk.SnapshotDownload, error) {
+	c.record("DownloadSnapshot", snapshotId)
+
+	if c.DownloadSnapshotFunc == nil {
+		return nil, fmt.Errorf("lynxmock: DownloadSnapshot is not implemented")
+	}
+
+	return c.DownloadSnapshotFunc(ctx, snapshotId, w)
+}
+
+// UploadSnapshot calls UploadSnapshotFunc
+func (c *Client) UploadSnapshot(ctx context.Context, snapshot sdk.Snapshot, file string) (*sdk.Snapshot, error) {
+	c.record("UploadSnapshot", snapshot, file)
+
+	if c.UploadSnapshotFunc == nil {
+		return nil, fmt.Errorf("lynxmock: UploadSnapshot is not implemented")
+	}
+
+	return c.UploadSnapshotFunc(ctx, snapshot, file)
+}
+
+// GetTask calls GetTaskFunc
+func (c *Client) GetTask(ctx context.Context, taskId string) (*sdk.Task, error) {
+	c.record("GetTask", taskId)
+
+	if c.GetTaskFunc == nil {
+		return nil, fmt.Errorf("lynxmock: GetTask is not implemented")
+	}
+
+	return c.GetTaskFunc(ctx, taskId)
+}
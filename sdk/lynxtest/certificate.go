@@ -0,0 +1,135 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package lynxtest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Certificate - A certificate and its private key generated for tests
+type Certificate struct {
+	Certificate *x509.Certificate
+	Key         *ecdsa.PrivateKey
+
+	// CertPEM and KeyPEM hold the PEM encoded certificate and key
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+// NewCA generates a self signed certificate authority
+func NewCA() (*Certificate, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Lynx Test CA"},
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	return newCertificate(template, nil)
+}
+
+// Issue generates a certificate signed by the authority for the given
+// hosts, it is valid for both server and client authentication
+func (c *Certificate) Issue(hosts ...string) (*Certificate, error) {
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "Lynx Test"},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	return newCertificate(template, c)
+}
+
+// TLSCertificate returns the certificate to serve or to present as a client
+func (c *Certificate) TLSCertificate() (tls.Certificate, error) {
+	return tls.X509KeyPair(c.CertPEM, c.KeyPEM)
+}
+
+// WriteFiles writes the certificate and the key to <name>.pem and
+// <name>-key.pem in the directory and returns their paths
+func (c *Certificate) WriteFiles(dir, name string) (string, string, error) {
+	certFile := filepath.Join(dir, name+".pem")
+	keyFile := filepath.Join(dir, name+"-key.pem")
+
+	if err := os.WriteFile(certFile, c.CertPEM, 0o600); err != nil {
+		return "", "", err
+	}
+
+	if err := os.WriteFile(keyFile, c.KeyPEM, 0o600); err != nil {
+		return "", "", err
+	}
+
+	return certFile, keyFile, nil
+}
+
+// newCertificate generates a key and signs the template with the parent,
+// the certificate is self signed without parent
+func newCertificate(template *x509.Certificate, parent *Certificate) (*Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+
+	if err != nil {
+		return nil, err
+	}
+
+	template.SerialNumber = serial
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(24 * time.Hour)
+
+	issuer, signer := template, key
+
+	if parent != nil {
+		issuer, signer = parent.Certificate, parent.Key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Certificate{
+		Certificate: cert,
+		Key:         key,
+		CertPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
@@ -0,0 +1,1153 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package lynxtest provides an in-memory fake of the Lynx API to test the
+// sdk and the provider offline.
+package lynxtest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+)
+
+// APIPrefix - Path prefix of the fake Lynx API
+const APIPrefix = "/api/v1"
+
+// Fault - An error the server returns instead of handling a request
+type Fault struct {
+	// Method to match, empty matches any method
+	Method string
+
+	// Path to match relative to the API prefix like /user, empty matches any path
+	Path string
+
+	// StatusCode of the error response
+	StatusCode int
+
+	// Message of the error response
+	Message string
+
+	// Headers of the error response like Retry-After
+	Headers map[string]string
+
+	// Times the fault is returned, zero returns it forever
+	Times int
+}
+
+// Request - A request received by the server
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+}
+
+// Version - Version the fake Lynx API reports
+const Version = "0.0.0-lynxtest"
+
+// Server - In memory fake of the Lynx API
+type Server struct {
+	*httptest.Server
+
+	APIKey string
+
+	mutex        sync.Mutex
+	sequence     int
+	faults       []*Fault
+	requests     []Request
+	sessions     map[string]string
+	users        *store[sdk.User]
+	passwords    map[string]string
+	teams        *store[sdk.Team]
+	projects     *store[sdk.Project]
+	environments *store[sdk.Environment]
+	snapshots    *store[sdk.Snapshot]
+	tasks        *store[sdk.Task]
+	archives     map[string][]byte
+	taskFailure  string
+	badChecksum  bool
+	partialTeams bool
+}
+
+// NewServer starts a new fake Lynx API that accepts the given API key
+func NewServer(apiKey string) *Server {
+	s := &Server{
+		APIKey:       apiKey,
+		sessions:     map[string]string{},
+		users:        newStore[sdk.User](),
+		passwords:    map[string]string{},
+		teams:        newStore[sdk.Team](),
+		projects:     newStore[sdk.Project](),
+		environments: newStore[sdk.Environment](),
+		snapshots:    newStore[sdk.Snapshot](),
+		tasks:        newStore[sdk.Task](),
+		archives:     map[string][]byte{},
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /action/auth", s.login)
+	mux.HandleFunc("GET /info", s.info)
+
+	mux.HandleFunc("GET /user", s.listUsers)
+	mux.HandleFunc("POST /user", s.createUser)
+	mux.HandleFunc("GET /user/{id}", s.getUser)
+	mux.HandleFunc("PUT /user/{id}", s.updateUser)
+	mux.HandleFunc("DELETE /user/{id}", s.deleteUser)
+
+	mux.HandleFunc("GET /team", s.listTeams)
+	mux.HandleFunc("POST /team", s.createTeam)
+	mux.HandleFunc("GET /team/{id}", s.getTeam)
+	mux.HandleFunc("PUT /team/{id}", s.updateTeam)
+	mux.HandleFunc("DELETE /team/{id}", s.deleteTeam)
+
+	mux.HandleFunc("GET /project", s.listProjects)
+	mux.HandleFunc("POST /project", s.createProject)
+	mux.HandleFunc("GET /project/{id}", s.getProject)
+	mux.HandleFunc("PUT /project/{id}", s.updateProject)
+	mux.HandleFunc("DELETE /project/{id}", s.deleteProject)
+
+	mux.HandleFunc("GET /project/{pid}/environment", s.listEnvironments)
+	mux.HandleFunc("POST /project/{pid}/environment", s.createEnvironment)
+	mux.HandleFunc("GET /project/{pid}/environment/{id}", s.getEnvironment)
+	mux.HandleFunc("PUT /project/{pid}/environment/{id}", s.updateEnvironment)
+	mux.HandleFunc("DELETE /project/{pid}/environment/{id}", s.deleteEnvironment)
+
+	mux.HandleFunc("GET /snapshot", s.listSnapshots)
+	mux.HandleFunc("POST /snapshot", s.createSnapshot)
+	mux.HandleFunc("POST /snapshot/upload", s.uploadSnapshot)
+	mux.HandleFunc("GET /snapshot/{id}", s.getSnapshot)
+	mux.HandleFunc("DELETE /snapshot/{id}", s.deleteSnapshot)
+	mux.HandleFunc("POST /snapshot/{id}/restore", s.restoreSnapshot)
+	mux.HandleFunc("GET /snapshot/{id}/download", s.downloadSnapshot)
+
+	mux.HandleFunc("GET /task/{id}", s.getTask)
+
+	s.Server = httptest.NewServer(http.StripPrefix(APIPrefix, s.middleware(mux)))
+
+	return s
+}
+
+// APIURL returns the URL to configure the sdk client or the provider with
+func (s *Server) APIURL() string {
+	return s.URL + APIPrefix
+}
+
+// Client returns an sdk client for the server with fast retries
+func (s *Server) Client() *sdk.Client {
+	client := sdk.NewClient(s.APIURL(), s.APIKey)
+	client.RetryWaitMin = time.Millisecond
+	client.RetryWaitMax = 10 * time.Millisecond
+
+	return client
+}
+
+// AddFault makes the server fail the matching requests
+func (s *Server) AddFault(fault Fault) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// Requests returns the requests received so far
+func (s *Server) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]Request{}, s.requests...)
+}
+
+// CountRequests counts the received requests with the given method and path
+func (s *Server) CountRequests(method, path string) int {
+	count := 0
+
+	for _, req := range s.Requests() {
+		if req.Method == method && req.Path == path {
+			count++
+		}
+	}
+
+	return count
+}
+
+// SetUserPassword sets the password a user logs in with
+func (s *Server) SetUserPassword(email, password string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.passwords[email] = password
+}
+
+// ExpireSessions invalidates all the session tokens
+func (s *Server) ExpireSessions() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sessions = map[string]string{}
+}
+
+// FailTasks makes the next tasks fail with the given message, an empty
+// message makes them succeed again
+func (s *Server) FailTasks(message string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.taskFailure = message
+}
+
+// CorruptDownloads makes the snapshot downloads send a checksum that
+// doesn't match their data
+func (s *Server) CorruptDownloads(corrupt bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.badChecksum = corrupt
+}
+
+// PartialTeamUpdates makes the team updates keep the fields missing from
+// the request body instead of replacing the whole team
+func (s *Server) PartialTeamUpdates(partial bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.partialTeams = partial
+}
+
+// middleware records the requests, returns the injected faults and checks the API key
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Header: r.Header.Clone()})
+
+		fault := s.matchFault(r)
+		_, session := s.sessions[r.Header.Get("X-API-Key")]
+		authorized := session || (s.APIKey != "" && r.Header.Get("X-API-Key") == s.APIKey)
+
+		s.mutex.Unlock()
+
+		if fault != nil {
+			for name, value := range fault.Headers {
+				w.Header().Set(name, value)
+			}
+
+			writeError(w, fault.StatusCode, fault.Message)
+			return
+		}
+
+		if !authorized && r.URL.Path != "/action/auth" {
+			writeError(w, http.StatusUnauthorized, "Invalid API key")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// matchFault returns the first fault matching the request and consumes it
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+
+		if fault.Path != "" && fault.Path != r.URL.Path {
+			continue
+		}
+
+		if fault.Times > 0 {
+			fault.Times--
+
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		return fault
+	}
+
+	return nil
+}
+
+// nextID generates a new UUID like identifier
+func (s *Server) nextID() string {
+	s.sequence++
+
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.sequence)
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	payload := map[string]string{}
+
+	if !decode(w, r, &payload) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	password, ok := s.passwords[payload["email"]]
+
+	if !ok || password != payload["password"] {
+		writeError(w, http.StatusUnauthorized, "Invalid email or password")
+		return
+	}
+
+	user, _ := s.users.find(func(user sdk.User) bool { return user.Email == payload["email"] })
+
+	token := "session-" + s.nextID()
+	s.sessions[token] = user.ID
+
+	writeJSON(w, http.StatusOK, sdk.Session{Token: token, UserID: user.ID})
+}
+
+func (s *Server) info(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, sdk.ServerInfo{Version: Version})
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	items, metadata := paginate(r, s.users.list(nil))
+
+	writeJSON(w, http.StatusOK, sdk.UserList{Users: items, Metadata: metadata})
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	user := sdk.User{}
+
+	if !decode(w, r, &user) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if user.Name == "" || user.Email == "" || user.Role == "" || user.Password == "" {
+		writeError(w, http.StatusBadRequest, "Name, email, role and password are required")
+		return
+	}
+
+	if _, ok := s.users.find(func(item sdk.User) bool { return item.Email == user.Email }); ok {
+		writeError(w, http.StatusConflict, "Email is already used")
+		return
+	}
+
+	user.ID = s.nextID()
+	s.passwords[user.Email] = user.Password
+	user.Password = ""
+	s.users.set(user.ID, user)
+
+	writeJSON(w, http.StatusCreated, user)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	user, ok := s.users.get(r.PathValue("id"))
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	user := sdk.User{}
+
+	if !decode(w, r, &user) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, ok := s.users.get(r.PathValue("id"))
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	if _, ok := s.users.find(func(item sdk.User) bool { return item.Email == user.Email && item.ID != current.ID }); ok {
+		writeError(w, http.StatusConflict, "Email is already used")
+		return
+	}
+
+	if user.Password != "" {
+		delete(s.passwords, current.Email)
+		s.passwords[user.Email] = user.Password
+	}
+
+	current.Name = user.Name
+	current.Email = user.Email
+	current.Role = user.Role
+	s.users.set(current.ID, current)
+
+	writeJSON(w, http.StatusOK, current)
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.users.delete(r.PathValue("id")) {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	slug := r.URL.Query().Get("slug")
+
+	items, metadata := paginate(r, s.teams.list(func(team sdk.Team) bool {
+		return slug == "" || team.Slug == slug
+	}))
+
+	writeJSON(w, http.StatusOK, sdk.TeamList{Teams: items, Metadata: metadata})
+}
+
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request) {
+	team := sdk.Team{}
+
+	if !decode(w, r, &team) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.validateTeam(w, team, "") {
+		return
+	}
+
+	team.ID = s.nextID()
+	s.teams.set(team.ID, team)
+
+	writeJSON(w, http.StatusCreated, team)
+}
+
+func (s *Server) getTeam(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	team, ok := s.teams.get(r.PathValue("id"))
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, team)
+}
+
+func (s *Server) updateTeam(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, ok := s.teams.get(r.PathValue("id"))
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	team := sdk.Team{}
+
+	// Decoding into the current team keeps the fields that aren't sent
+	if s.partialTeams {
+		team = current
+	}
+
+	if !decode(w, r, &team) {
+		return
+	}
+
+	if !s.validateTeam(w, team, current.ID) {
+		return
+	}
+
+	team.ID = current.ID
+	s.teams.set(team.ID, team)
+
+	writeJSON(w, http.StatusOK, team)
+}
+
+func (s *Server) deleteTeam(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.teams.delete(r.PathValue("id")) {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateTeam checks the team fields, the slug uniqueness and the members
+func (s *Server) validateTeam(w http.ResponseWriter, team sdk.Team, id string) bool {
+	if team.Name == "" || team.Slug == "" {
+		writeError(w, http.StatusBadRequest, "Name and slug are required")
+		return false
+	}
+
+	if !sdk.ValidSlug(team.Slug) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Slug %s is invalid", team.Slug))
+		return false
+	}
+
+	if _, ok := s.teams.find(func(item sdk.Team) bool { return item.Slug == team.Slug && item.ID != id }); ok {
+		writeError(w, http.StatusConflict, "Slug is already used")
+		return false
+	}
+
+	for _, member := range team.Members {
+		if _, ok := s.users.get(member); !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("User %s not found", member))
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	slug := r.URL.Query().Get("slug")
+	teamID := r.URL.Query().Get("team_id")
+
+	items, metadata := paginate(r, s.projects.list(func(project sdk.Project) bool {
+		return (slug == "" || project.Slug == slug) && (teamID == "" || project.Team.ID == teamID)
+	}))
+
+	for i := range items {
+		items[i] = s.expandProject(items[i])
+	}
+
+	writeJSON(w, http.StatusOK, sdk.ProjectList{Projects: items, Metadata: metadata})
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	project := sdk.Project{}
+
+	if !decode(w, r, &project) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.validateProject(w, project, "") {
+		return
+	}
+
+	project.ID = s.nextID()
+	project.Team = sdk.Team{ID: project.TeamId}
+	project.TeamId = ""
+	s.projects.set(project.ID, project)
+
+	writeJSON(w, http.StatusCreated, s.expandProject(project))
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	project, ok := s.projects.get(r.PathValue("id"))
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.expandProject(project))
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	project := sdk.Project{}
+
+	if !decode(w, r, &project) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, ok := s.projects.get(r.PathValue("id"))
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	if !s.validateProject(w, project, current.ID) {
+		return
+	}
+
+	project.ID = current.ID
+	project.Team = sdk.Team{ID: project.TeamId}
+	project.TeamId = ""
+	s.projects.set(project.ID, project)
+
+	writeJSON(w, http.StatusOK, s.expandProject(project))
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.projects.delete(r.PathValue("id")) {
+		writeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateProject checks the project fields, the slug uniqueness and the team
+func (s *Server) validateProject(w http.ResponseWriter, project sdk.Project, id string) bool {
+	if project.Name == "" || project.Slug == "" || project.TeamId == "" {
+		writeError(w, http.StatusBadRequest, "Name, slug and team_id are required")
+		return false
+	}
+
+	if !sdk.ValidSlug(project.Slug) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Slug %s is invalid", project.Slug))
+		return false
+	}
+
+	if _, ok := s.teams.get(project.TeamId); !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Team %s not found", project.TeamId))
+		return false
+	}
+
+	if _, ok := s.projects.find(func(item sdk.Project) bool { return item.Slug == project.Slug && item.ID != id }); ok {
+		writeError(w, http.StatusConflict, "Slug is already used")
+		return false
+	}
+
+	return true
+}
+
+// expandProject embeds the project team like Lynx does
+func (s *Server) expandProject(project sdk.Project) sdk.Project {
+	if team, ok := s.teams.get(project.Team.ID); ok {
+		project.Team = team
+	}
+
+	return project
+}
+
+func (s *Server) listEnvironments(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	projectID := r.PathValue("pid")
+
+	if _, ok := s.projects.get(projectID); !ok {
+		writeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	slug := r.URL.Query().Get("slug")
+
+	items, metadata := paginate(r, s.environments.list(func(environment sdk.Environment) bool {
+		return environment.Project.ID == projectID && (slug == "" || environment.Slug == slug)
+	}))
+
+	for i := range items {
+		items[i] = s.expandEnvironment(items[i])
+	}
+
+	writeJSON(w, http.StatusOK, sdk.EnvironmentList{Environments: items, Metadata: metadata})
+}
+
+func (s *Server) createEnvironment(w http.ResponseWriter, r *http.Request) {
+	environment := sdk.Environment{}
+
+	if !decode(w, r, &environment) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	projectID := r.PathValue("pid")
+
+	if !s.validateEnvironment(w, projectID, environment, "") {
+		return
+	}
+
+	environment.ID = s.nextID()
+	environment.Project = sdk.Project{ID: projectID}
+	s.environments.set(environment.ID, environment)
+
+	writeJSON(w, http.StatusCreated, s.expandEnvironment(environment))
+}
+
+func (s *Server) getEnvironment(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	environment, ok := s.environments.get(r.PathValue("id"))
+
+	if !ok || environment.Project.ID != r.PathValue("pid") {
+		writeError(w, http.StatusNotFound, "Environment not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.expandEnvironment(environment))
+}
+
+func (s *Server) updateEnvironment(w http.ResponseWriter, r *http.Request) {
+	environment := sdk.Environment{}
+
+	if !decode(w, r, &environment) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, ok := s.environments.get(r.PathValue("id"))
+
+	if !ok || current.Project.ID != r.PathValue("pid") {
+		writeError(w, http.StatusNotFound, "Environment not found")
+		return
+	}
+
+	if !s.validateEnvironment(w, current.Project.ID, environment, current.ID) {
+		return
+	}
+
+	environment.ID = current.ID
+	environment.Project = current.Project
+	s.environments.set(environment.ID, environment)
+
+	writeJSON(w, http.StatusOK, s.expandEnvironment(environment))
+}
+
+func (s *Server) deleteEnvironment(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	environment, ok := s.environments.get(r.PathValue("id"))
+
+	if !ok || environment.Project.ID != r.PathValue("pid") {
+		writeError(w, http.StatusNotFound, "Environment not found")
+		return
+	}
+
+	s.environments.delete(environment.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateEnvironment checks the environment fields, the project and the slug uniqueness within the project
+func (s *Server) validateEnvironment(w http.ResponseWriter, projectID string, environment sdk.Environment, id string) bool {
+	if _, ok := s.projects.get(projectID); !ok {
+		writeError(w, http.StatusNotFound, "Project not found")
+		return false
+	}
+
+	if environment.Name == "" || environment.Slug == "" || environment.Username == "" || environment.Secret == "" {
+		writeError(w, http.StatusBadRequest, "Name, slug, username and secret are required")
+		return false
+	}
+
+	if !sdk.ValidSlug(environment.Slug) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Slug %s is invalid", environment.Slug))
+		return false
+	}
+
+	if _, ok := s.environments.find(func(item sdk.Environment) bool {
+		return item.Project.ID == projectID && item.Slug == environment.Slug && item.ID != id
+	}); ok {
+		writeError(w, http.StatusConflict, "Slug is already used")
+		return false
+	}
+
+	return true
+}
+
+// expandEnvironment embeds the environment project like Lynx does
+func (s *Server) expandEnvironment(environment sdk.Environment) sdk.Environment {
+	if project, ok := s.projects.get(environment.Project.ID); ok {
+		environment.Project = s.expandProject(project)
+	}
+
+	return environment
+}
+
+func (s *Server) listSnapshots(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	teamID := r.URL.Query().Get("team_id")
+	recordType := r.URL.Query().Get("record_type")
+
+	items, metadata := paginate(r, s.snapshots.list(func(snapshot sdk.Snapshot) bool {
+		return (teamID == "" || snapshot.Team.ID == teamID) && (recordType == "" || snapshot.RecordType == recordType)
+	}))
+
+	for i := range items {
+		items[i] = s.expandSnapshot(items[i])
+	}
+
+	writeJSON(w, http.StatusOK, sdk.SnapshotList{Snapshots: items, Metadata: metadata})
+}
+
+func (s *Server) createSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot := sdk.Snapshot{}
+
+	if !decode(w, r, &snapshot) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.saveSnapshot(w, snapshot, nil)
+}
+
+func (s *Server) uploadSnapshot(w http.ResponseWriter, r *http.Request) {
+	form, err := r.MultipartReader()
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err.Error()))
+		return
+	}
+
+	snapshot := sdk.Snapshot{}
+	var archive []byte
+
+	for {
+		part, err := form.NextPart()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err.Error()))
+			return
+		}
+
+		switch part.FormName() {
+		case "snapshot":
+			err = json.NewDecoder(part).Decode(&snapshot)
+		case "file":
+			archive, err = io.ReadAll(part)
+		}
+
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err.Error()))
+			return
+		}
+	}
+
+	if len(archive) == 0 {
+		writeError(w, http.StatusBadRequest, "Snapshot file is required")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.saveSnapshot(w, snapshot, archive)
+}
+
+// saveSnapshot validates and stores a new snapshot, the archive holds the
+// data of an uploaded snapshot
+func (s *Server) saveSnapshot(w http.ResponseWriter, snapshot sdk.Snapshot, archive []byte) {
+	if snapshot.Title == "" || snapshot.RecordType == "" || snapshot.RecordID == "" || snapshot.TeamId == "" {
+		writeError(w, http.StatusBadRequest, "Title, record_type, record_uuid and team_id are required")
+		return
+	}
+
+	if _, ok := s.teams.get(snapshot.TeamId); !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Team %s not found", snapshot.TeamId))
+		return
+	}
+
+	if !s.recordExists(snapshot.RecordType, snapshot.RecordID) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Record %s with type %s not found", snapshot.RecordID, snapshot.RecordType))
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	// Lynx takes snapshots in the background with a task
+	task := sdk.Task{
+		ID:        s.nextID(),
+		Status:    sdk.TaskPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	s.tasks.set(task.ID, task)
+
+	snapshot.ID = s.nextID()
+	snapshot.Team = sdk.Team{ID: snapshot.TeamId}
+	snapshot.TeamId = ""
+	snapshot.Status = sdk.TaskPending
+	snapshot.TaskID = task.ID
+	snapshot.CreatedAt = now
+	snapshot.UpdatedAt = now
+	s.snapshots.set(snapshot.ID, snapshot)
+
+	if archive != nil {
+		s.archives[snapshot.ID] = archive
+	}
+
+	writeJSON(w, http.StatusCreated, s.expandSnapshot(snapshot))
+}
+
+func (s *Server) getSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot, ok := s.snapshots.get(r.PathValue("id"))
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Snapshot not found")
+		return
+	}
+
+	// A pending snapshot finishes once it is polled
+	if snapshot.Status == sdk.TaskPending {
+		task, _ := s.tasks.get(snapshot.TaskID)
+		task.Status = sdk.TaskSuccess
+		task.Result = "Snapshot taken"
+
+		if s.taskFailure != "" {
+			task.Status = sdk.TaskFailure
+			task.Result = s.taskFailure
+		}
+
+		task.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+		s.tasks.set(task.ID, task)
+
+		snapshot.Status = task.Status
+		snapshot.UpdatedAt = task.UpdatedAt
+		s.snapshots.set(snapshot.ID, snapshot)
+	}
+
+	writeJSON(w, http.StatusOK, s.expandSnapshot(snapshot))
+}
+
+func (s *Server) deleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.snapshots.delete(r.PathValue("id")) {
+		writeError(w, http.StatusNotFound, "Snapshot not found")
+		return
+	}
+
+	delete(s.archives, r.PathValue("id"))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) restoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	restore := sdk.SnapshotRestore{}
+
+	if !decode(w, r, &restore) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot, ok := s.snapshots.get(r.PathValue("id"))
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Snapshot not found")
+		return
+	}
+
+	if restore.RecordType != snapshot.RecordType || !s.recordExists(restore.RecordType, restore.RecordID) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Record %s with type %s can't be restored from the snapshot", restore.RecordID, restore.RecordType))
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	task := sdk.Task{
+		ID:        s.nextID(),
+		Status:    sdk.TaskPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	s.tasks.set(task.ID, task)
+
+	writeJSON(w, http.StatusAccepted, task)
+}
+
+// getTask advances the task on every call, from pending to running to
+// success or failure
+func (s *Server) downloadSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot, ok := s.snapshots.get(r.PathValue("id"))
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Snapshot not found")
+		return
+	}
+
+	if snapshot.Status != sdk.TaskSuccess {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Snapshot %s is not ready", snapshot.ID))
+		return
+	}
+
+	// The data of a snapshot is the snapshot itself, it never changes
+	// once the snapshot is taken. Uploaded snapshots keep their archive
+	data, ok := s.archives[snapshot.ID]
+
+	if !ok {
+		data, _ = json.Marshal(s.expandSnapshot(snapshot))
+	}
+	checksum := sha256.Sum256(data)
+
+	if s.badChecksum {
+		checksum = sha256.Sum256(nil)
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set(sdk.ChecksumHeader, hex.EncodeToString(checksum[:]))
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = w.Write(data)
+}
+
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	task, ok := s.tasks.get(r.PathValue("id"))
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Task not found")
+		return
+	}
+
+	switch task.Status {
+	case sdk.TaskPending:
+		task.Status = sdk.TaskRunning
+	case sdk.TaskRunning:
+		task.Status = sdk.TaskSuccess
+		task.Result = "Snapshot restored"
+
+		if s.taskFailure != "" {
+			task.Status = sdk.TaskFailure
+			task.Result = s.taskFailure
+		}
+	}
+
+	task.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	s.tasks.set(task.ID, task)
+
+	writeJSON(w, http.StatusOK, task)
+}
+
+// recordExists checks if the snapshot record exists
+func (s *Server) recordExists(recordType, recordID string) bool {
+	switch recordType {
+	case "project":
+		_, ok := s.projects.get(recordID)
+		return ok
+	case "environment":
+		_, ok := s.environments.get(recordID)
+		return ok
+	}
+
+	return false
+}
+
+// expandSnapshot embeds the snapshot team like Lynx does
+func (s *Server) expandSnapshot(snapshot sdk.Snapshot) sdk.Snapshot {
+	if team, ok := s.teams.get(snapshot.Team.ID); ok {
+		snapshot.Team = team
+	}
+
+	return snapshot
+}
+
+// paginate applies the offset and limit query parameters
+func paginate[T any](r *http.Request, items []T) ([]T, sdk.Metadata) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	if err != nil || limit <= 0 {
+		limit = sdk.DefaultListLimit
+	}
+
+	metadata := sdk.Metadata{Offset: offset, Limit: limit, TotalCount: len(items)}
+
+	if offset >= len(items) || offset < 0 {
+		return []T{}, metadata
+	}
+
+	end := offset + limit
+
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[offset:end], metadata
+}
+
+// decode reads the JSON request body
+func decode(w http.ResponseWriter, r *http.Request, value interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(value); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err.Error()))
+		return false
+	}
+
+	return true
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, statusCode int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes an error response the way Lynx does
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	if message == "" {
+		message = strings.ToLower(http.StatusText(statusCode))
+	}
+
+	writeJSON(w, statusCode, map[string]string{"errorMessage": message})
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package lynxtest
+
+// store keeps the objects of a type in creation order
+type store[T any] struct {
+	ids   []string
+	items map[string]T
+}
+
+// newStore creates a new store
+func newStore[T any]() *store[T] {
+	return &store[T]{items: map[string]T{}}
+}
+
+// get returns an object by ID
+func (s *store[T]) get(id string) (T, bool) {
+	item, ok := s.items[id]
+
+	return item, ok
+}
+
+// set creates or replaces an object
+func (s *store[T]) set(id string, item T) {
+	if _, ok := s.items[id]; !ok {
+		s.ids = append(s.ids, id)
+	}
+
+	s.items[id] = item
+}
+
+// delete removes an object and reports if it existed
+func (s *store[T]) delete(id string) bool {
+	if _, ok := s.items[id]; !ok {
+		return false
+	}
+
+	delete(s.items, id)
+
+	for i, item := range s.ids {
+		if item == id {
+			s.ids = append(s.ids[:i], s.ids[i+1:]...)
+			break
+		}
+	}
+
+	return true
+}
+
+// list returns the objects matching the filter in creation order
+func (s *store[T]) list(match func(item T) bool) []T {
+	items := []T{}
+
+	for _, id := range s.ids {
+		if match == nil || match(s.items[id]) {
+			items = append(items, s.items[id])
+		}
+	}
+
+	return items
+}
+
+// find returns the first object matching the filter
+func (s *store[T]) find(match func(item T) bool) (T, bool) {
+	items := s.list(match)
+
+	if len(items) == 0 {
+		var empty T
+
+		return empty, false
+	}
+
+	return items[0], true
+}
@@ -9,6 +9,19 @@ const (
 	SuperUser   = "super"
 )
 
+const (
+	ProjectRecord     = "project"
+	EnvironmentRecord = "environment"
+)
+
+// Status of a task, snapshots share it with the task taking them
+const (
+	TaskPending = "pending"
+	TaskRunning = "running"
+	TaskSuccess = "success"
+	TaskFailure = "failure"
+)
+
 // User Model
 type User struct {
 	ID       string `json:"id,omitempty"`
@@ -27,6 +40,13 @@ type Team struct {
 	Members     []string `json:"members,omitempty"`
 }
 
+// teamUpdate - Team update payload sending the fields that can be cleared
+type teamUpdate struct {
+	Team
+	Description string   `json:"description"`
+	Members     []string `json:"members"`
+}
+
 // Project Model
 type Project struct {
 	ID          string `json:"id,omitempty"`
@@ -56,4 +76,71 @@ type Snapshot struct {
 	RecordID    string `json:"record_uuid,omitempty"`
 	TeamId      string `json:"team_id,omitempty"`
 	Team        Team   `json:"team,omitempty"`
+	Status      string `json:"status,omitempty"`
+	TaskID      string `json:"task_id,omitempty"`
+	CreatedAt   string `json:"createdAt,omitempty"`
+	UpdatedAt   string `json:"updatedAt,omitempty"`
+}
+
+// SnapshotRestore Model
+type SnapshotRestore struct {
+	RecordType string `json:"record_type,omitempty"`
+	RecordID   string `json:"record_uuid,omitempty"`
+}
+
+// SnapshotDownload Model
+type SnapshotDownload struct {
+	Size   int64
+	SHA256 string
+}
+
+// Task Model
+type Task struct {
+	ID        string `json:"id,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Result    string `json:"result,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// Metadata Model
+type Metadata struct {
+	Offset     int `json:"offset"`
+	Limit      int `json:"limit"`
+	TotalCount int `json:"totalCount"`
+}
+
+// UserList Model
+type UserList struct {
+	Users    []User   `json:"users"`
+	Metadata Metadata `json:"_metadata"`
+}
+
+// TeamList Model
+type TeamList struct {
+	Teams    []Team   `json:"teams"`
+	Metadata Metadata `json:"_metadata"`
+}
+
+// ProjectList Model
+type ProjectList struct {
+	Projects []Project `json:"projects"`
+	Metadata Metadata  `json:"_metadata"`
+}
+
+// EnvironmentList Model
+type EnvironmentList struct {
+	Environments []Environment `json:"environments"`
+	Metadata     Metadata      `json:"_metadata"`
+}
+
+// SnapshotList Model
+type SnapshotList struct {
+	Snapshots []Snapshot `json:"snapshots"`
+	Metadata  Metadata   `json:"_metadata"`
+}
+
+// ServerInfo Model
+type ServerInfo struct {
+	Version string `json:"version,omitempty"`
 }
@@ -0,0 +1,131 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultProfile - Name of the profile used when none is selected
+const DefaultProfile = "default"
+
+// ErrProfileNotFound - Returned when the credentials file has no such profile
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Profile - Named connection settings of a Lynx instance
+type Profile struct {
+	Name               string
+	APIURL             string
+	APIKey             string
+	CACertFile         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	InsecureSkipVerify bool
+}
+
+// DefaultConfigFile - Returns the path of the shared credentials file
+// ~/.lynx/credentials
+func DefaultConfigFile() (string, error) {
+	home, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", fmt.Errorf("unable to find the home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".lynx", "credentials"), nil
+}
+
+// LoadProfile - Loads a profile from an INI credentials file like
+//
+//	[default]
+//	api_url = https://lynx.example.com/api/v1
+//	api_key = ~api-key-here~
+//
+//	[staging]
+//	api_url = https://lynx.staging.example.com/api/v1
+//	api_key = ~api-key-here~
+//	ca_cert_file = /etc/ssl/lynx-staging.pem
+func LoadProfile(file, name string) (*Profile, error) {
+	content, err := os.Open(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read credentials file %s: %w", file, err)
+	}
+
+	defer content.Close()
+
+	var profile *Profile
+
+	section := ""
+	scanner := bufio.NewScanner(content)
+
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+
+			if section == name {
+				profile = &Profile{Name: name}
+			}
+
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+
+		if !ok || section == "" {
+			return nil, fmt.Errorf("invalid line %d in credentials file %s", number, file)
+		}
+
+		if section != name {
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), "\"'")
+
+		switch key {
+		case "api_url":
+			profile.APIURL = value
+		case "api_key":
+			profile.APIKey = value
+		case "ca_cert_file":
+			profile.CACertFile = value
+		case "client_cert_file":
+			profile.ClientCertFile = value
+		case "client_key_file":
+			profile.ClientKeyFile = value
+		case "insecure_skip_verify":
+			profile.InsecureSkipVerify, err = strconv.ParseBool(value)
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid insecure_skip_verify value %s on line %d in credentials file %s", value, number, file)
+			}
+		default:
+			return nil, fmt.Errorf("unknown key %s on line %d in credentials file %s", key, number, file)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read credentials file %s: %w", file, err)
+	}
+
+	if profile == nil {
+		return nil, fmt.Errorf("%w: %s in credentials file %s", ErrProfileNotFound, name, file)
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+)
+
+// TestUnitLoadProfile tests loading profiles from a credentials file
+func TestUnitLoadProfile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "credentials")
+
+	err := os.WriteFile(file, []byte(`
+# Lynx instances
+[default]
+api_url = http://localhost:4000/api/v1
+api_key = "default-key"
+
+[staging]
+api_url = https://lynx.staging.example.com/api/v1
+api_key = staging-key
+ca_cert_file = /etc/ssl/lynx.pem
+insecure_skip_verify = true
+`), 0o600)
+
+	if err != nil {
+		t.Fatalf("unable to write credentials file: %s", err)
+	}
+
+	profile, err := sdk.LoadProfile(file, "staging")
+
+	if err != nil {
+		t.Fatalf("unable to load profile: %s", err)
+	}
+
+	expected := sdk.Profile{
+		Name:               "staging",
+		APIURL:             "https://lynx.staging.example.com/api/v1",
+		APIKey:             "staging-key",
+		CACertFile:         "/etc/ssl/lynx.pem",
+		InsecureSkipVerify: true,
+	}
+
+	if *profile != expected {
+		t.Errorf("expected profile %+v, got %+v", expected, *profile)
+	}
+
+	profile, err = sdk.LoadProfile(file, sdk.DefaultProfile)
+
+	if err != nil || profile.APIKey != "default-key" {
+		t.Errorf("expected the quotes to be removed from the default profile key, got %+v, %v", profile, err)
+	}
+
+	if _, err = sdk.LoadProfile(file, "prod"); !errors.Is(err, sdk.ErrProfileNotFound) {
+		t.Errorf("expected a profile not found error, got %v", err)
+	}
+
+	if err = os.WriteFile(file, []byte("[default]\napi_token = key\n"), 0o600); err != nil {
+		t.Fatalf("unable to write credentials file: %s", err)
+	}
+
+	if _, err = sdk.LoadProfile(file, sdk.DefaultProfile); err == nil {
+		t.Error("expected an error for an unknown key")
+	}
+}
@@ -5,6 +5,7 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -12,7 +13,7 @@ import (
 )
 
 // CreateProject - Creates a new Project
-func (c *Client) CreateProject(project Project) (*Project, error) {
+func (c *Client) CreateProject(ctx context.Context, project Project) (*Project, error) {
 
 	project.TeamId = project.Team.ID
 
@@ -22,7 +23,8 @@ func (c *Client) CreateProject(project Project) (*Project, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"POST",
 		fmt.Sprintf("%s/project", c.ApiURL),
 		strings.NewReader(string(rb)),
@@ -52,7 +54,7 @@ func (c *Client) CreateProject(project Project) (*Project, error) {
 }
 
 // UpdateProject - Updates a new Project
-func (c *Client) UpdateProject(project Project) (*Project, error) {
+func (c *Client) UpdateProject(ctx context.Context, project Project) (*Project, error) {
 
 	project.TeamId = project.Team.ID
 
@@ -62,7 +64,8 @@ func (c *Client) UpdateProject(project Project) (*Project, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"PUT",
 		fmt.Sprintf("%s/project/%s", c.ApiURL, project.ID),
 		strings.NewReader(string(rb)),
@@ -92,9 +95,10 @@ func (c *Client) UpdateProject(project Project) (*Project, error) {
 }
 
 // GetProject - Gets a new Project
-func (c *Client) GetProject(projectId string) (*Project, error) {
+func (c *Client) GetProject(ctx context.Context, projectId string) (*Project, error) {
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("%s/project/%s", c.ApiURL, projectId),
 		nil,
@@ -124,9 +128,10 @@ func (c *Client) GetProject(projectId string) (*Project, error) {
 }
 
 // DeleteProject - Deletes a Project
-func (c *Client) DeleteProject(projectId string) error {
+func (c *Client) DeleteProject(ctx context.Context, projectId string) error {
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"DELETE",
 		fmt.Sprintf("%s/project/%s", c.ApiURL, projectId),
 		nil,
@@ -144,3 +149,38 @@ func (c *Client) DeleteProject(projectId string) error {
 
 	return nil
 }
+
+// ListProjects - Lists projects
+func (c *Client) ListProjects(ctx context.Context, opts ListOptions) (*ProjectList, error) {
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s/project?%s", c.ApiURL, opts.query()),
+		nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list := ProjectList{}
+
+	err = json.Unmarshal(body, &list)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range list.Projects {
+		list.Projects[i].TeamId = list.Projects[i].Team.ID
+	}
+
+	return &list, nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries - Default number of retries for transient failures
+	DefaultMaxRetries = 3
+
+	// DefaultRetryWaitMin - Default minimum wait between two attempts
+	DefaultRetryWaitMin = 1 * time.Second
+
+	// DefaultRetryWaitMax - Default maximum wait between two attempts
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// isIdempotent checks if a request can be sent twice without side effects
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// shouldRetryError decides if a transport error is worth another attempt.
+// Non idempotent requests are only retried when the connection could not
+// be established, since the server never saw the request in that case.
+func shouldRetryError(method string, err error) bool {
+	var certErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+
+	if errors.As(err, &certErr) || errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) {
+		return false
+	}
+
+	var opErr *net.OpError
+
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	return isIdempotent(method)
+}
+
+// shouldRetryStatus decides if a response status is worth another attempt.
+// 429 and 503 mean the request was rejected before being processed, so they
+// are safe to retry for any method.
+func shouldRetryStatus(method string, statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+
+	return false
+}
+
+// retryAfter parses the Retry-After header which holds either a number of
+// seconds or an HTTP date. It returns zero if the header is missing or invalid.
+func retryAfter(res *http.Response) time.Duration {
+	if res == nil {
+		return 0
+	}
+
+	value := res.Header.Get("Retry-After")
+
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
+
+// backoff calculates the wait before the next attempt using exponential
+// backoff with jitter. A Retry-After header from the server takes precedence,
+// the result always stays between the minimum and the maximum wait.
+func (c *Client) backoff(attempt int, res *http.Response) time.Duration {
+	if wait := retryAfter(res); wait > 0 {
+		return c.clampWait(wait)
+	}
+
+	base := c.RetryWaitMin
+
+	for i := 0; i < attempt && base < c.RetryWaitMax; i++ {
+		base *= 2
+	}
+
+	base = c.clampWait(base)
+
+	// Pick a random wait between the backoff and its double to avoid all
+	// the clients retrying at the same moment. Once the backoff reaches the
+	// maximum wait, pick it between half and the full maximum instead.
+	lower, upper := base, min(2*base, c.RetryWaitMax)
+
+	if lower == upper {
+		lower = max(c.RetryWaitMin, base/2)
+	}
+
+	if upper > lower {
+		return lower + time.Duration(rand.Int63n(int64(upper-lower)+1))
+	}
+
+	return base
+}
+
+// clampWait bounds a wait to the minimum and the maximum wait
+func (c *Client) clampWait(wait time.Duration) time.Duration {
+	return min(max(wait, c.RetryWaitMin), c.RetryWaitMax)
+}
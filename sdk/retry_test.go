@@ -0,0 +1,69 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestUnitBackoff tests that the wait between attempts stays between the
+// minimum and the maximum wait
+func TestUnitBackoff(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		min        time.Duration
+		max        time.Duration
+		attempt    int
+		retryAfter string
+		lower      time.Duration
+		upper      time.Duration
+		jitter     bool
+	}{
+		// The provider rejects a zero minimum, the sdk then retries at once
+		{name: "zero min and max", attempt: 3},
+		{name: "zero min", max: time.Second, attempt: 3},
+		{name: "first attempt", min: time.Second, max: 30 * time.Second, lower: time.Second, upper: 2 * time.Second, jitter: true},
+		{name: "third attempt", min: time.Second, max: 30 * time.Second, attempt: 2, lower: 4 * time.Second, upper: 8 * time.Second, jitter: true},
+		{name: "capped attempt", min: time.Second, max: 30 * time.Second, attempt: 20, lower: 15 * time.Second, upper: 30 * time.Second, jitter: true},
+		{name: "min equals max", min: 5 * time.Second, max: 5 * time.Second, attempt: 4, lower: 5 * time.Second, upper: 5 * time.Second},
+		{name: "retry after", min: time.Second, max: 30 * time.Second, retryAfter: "5", lower: 5 * time.Second, upper: 5 * time.Second},
+		{name: "retry after above max", min: time.Second, max: 30 * time.Second, retryAfter: "120", lower: 30 * time.Second, upper: 30 * time.Second},
+		{name: "retry after date above max", min: time.Second, max: 30 * time.Second, retryAfter: time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), lower: 30 * time.Second, upper: 30 * time.Second},
+		{name: "retry after below min", min: 2 * time.Second, max: 30 * time.Second, retryAfter: "1", lower: 2 * time.Second, upper: 2 * time.Second},
+		{name: "retry after with zero min", max: 30 * time.Second, retryAfter: "3", lower: 3 * time.Second, upper: 3 * time.Second},
+		{name: "invalid retry after", min: time.Second, max: 30 * time.Second, retryAfter: "soon", lower: time.Second, upper: 2 * time.Second, jitter: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			client := NewClient("http://lynx.example.com/api/v1", "secret-key")
+			client.RetryWaitMin = test.min
+			client.RetryWaitMax = test.max
+
+			res := &http.Response{Header: http.Header{}}
+
+			if test.retryAfter != "" {
+				res.Header.Set("Retry-After", test.retryAfter)
+			}
+
+			// The jitter is random, so sample it a few times
+			waits := map[time.Duration]bool{}
+
+			for i := 0; i < 100; i++ {
+				wait := client.backoff(test.attempt, res)
+
+				if wait < test.lower || wait > test.upper {
+					t.Fatalf("expected a wait between %s and %s, got %s", test.lower, test.upper, wait)
+				}
+
+				waits[wait] = true
+			}
+
+			if test.jitter && len(waits) == 1 {
+				t.Errorf("expected the waits to be spread between %s and %s, got %v", test.lower, test.upper, waits)
+			}
+		})
+	}
+}
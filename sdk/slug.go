@@ -0,0 +1,83 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// SlugPattern - Format Lynx accepts for team, project and environment slugs
+var SlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// SlugMinLength and SlugMaxLength - Length bounds Lynx enforces on slugs
+const (
+	SlugMinLength = 2
+	SlugMaxLength = 60
+)
+
+// slugLetters - Latin letters without a unicode decomposition to ASCII
+var slugLetters = strings.NewReplacer(
+	"ß", "ss",
+	"æ", "ae",
+	"œ", "oe",
+	"ø", "o",
+	"đ", "d",
+	"ł", "l",
+	"þ", "th",
+	"ı", "i",
+)
+
+// ValidSlug - Reports whether Lynx accepts the slug
+func ValidSlug(slug string) bool {
+	return len(slug) >= SlugMinLength && len(slug) <= SlugMaxLength && SlugPattern.MatchString(slug)
+}
+
+// Slugify - Builds a slug from a name. Accents are removed, letters are
+// lowercased and any other character becomes a single hyphen, so
+// "Café Déjà Vu!" becomes "cafe-deja-vu". Slugs longer than SlugMaxLength
+// are truncated
+func Slugify(name string) (string, error) {
+	var slug strings.Builder
+
+	hyphen := false
+
+	for _, r := range slugLetters.Replace(strings.ToLower(norm.NFKD.String(name))) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining marks left by the decomposition of accented letters
+			continue
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			if hyphen && slug.Len() > 0 {
+				slug.WriteRune('-')
+			}
+
+			slug.WriteRune(r)
+			hyphen = false
+		default:
+			hyphen = true
+		}
+	}
+
+	if slug.Len() == 0 {
+		return "", fmt.Errorf("unable to build a slug from %q, it has no latin letters or digits", name)
+	}
+
+	result := slug.String()
+
+	if len(result) > SlugMaxLength {
+		result = strings.TrimRight(result[:SlugMaxLength], "-")
+	}
+
+	if len(result) < SlugMinLength {
+		return "", fmt.Errorf("unable to build a slug from %q, %q is shorter than %d characters", name, result, SlugMinLength)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+)
+
+// TestUnitSlugify tests building slugs from names
+func TestUnitSlugify(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Monitoring", expected: "monitoring"},
+		{name: "grafana-prod", expected: "grafana-prod"},
+		{name: "  System   Monitoring\tTeam\n", expected: "system-monitoring-team"},
+		{name: "Grafana (Prod)!", expected: "grafana-prod"},
+		{name: "--dev--", expected: "dev"},
+		{name: "a_b.c/d", expected: "a-b-c-d"},
+		{name: "Café Déjà Vu", expected: "cafe-deja-vu"},
+		{name: "Straße Ærø Łódź", expected: "strasse-aero-lodz"},
+		{name: "Ｆｕｌｌ Ｗｉｄｔｈ ①", expected: "full-width-1"},
+		{name: "İstanbul", expected: "istanbul"},
+		{name: "Team 2024", expected: "team-2024"},
+		{name: "監視 Team", expected: "team"},
+		{name: strings.Repeat("a", 59) + " b", expected: strings.Repeat("a", 59)},
+		{name: strings.Repeat("ab", 40), expected: strings.Repeat("ab", 30)},
+	}
+
+	for _, test := range tests {
+		slug, err := sdk.Slugify(test.name)
+
+		if err != nil {
+			t.Errorf("unable to slugify %q: %s", test.name, err)
+			continue
+		}
+
+		if slug != test.expected {
+			t.Errorf("expected slug of %q to be %q, got %q", test.name, test.expected, slug)
+		}
+
+		if !sdk.ValidSlug(slug) {
+			t.Errorf("expected slug %q to match the Lynx slug pattern", slug)
+		}
+	}
+
+	for _, name := range []string{"", "   ", "!!!", "監視", "A", "é!"} {
+		if _, err := sdk.Slugify(name); err == nil {
+			t.Errorf("expected an error for %q", name)
+		}
+	}
+}
+
+// TestUnitValidSlug tests the slugs accepted by Lynx
+func TestUnitValidSlug(t *testing.T) {
+	tests := []struct {
+		slug  string
+		valid bool
+	}{
+		{slug: "grafana-prod", valid: true},
+		{slug: "ab", valid: true},
+		{slug: strings.Repeat("a", 60), valid: true},
+		{slug: "a"},
+		{slug: strings.Repeat("a", 61)},
+		{slug: "Grafana"},
+		{slug: "-grafana"},
+		{slug: "grafana--prod"},
+		{slug: "grafana_prod"},
+	}
+
+	for _, test := range tests {
+		if sdk.ValidSlug(test.slug) != test.valid {
+			t.Errorf("expected ValidSlug(%q) to be %t", test.slug, test.valid)
+		}
+	}
+}
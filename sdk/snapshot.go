@@ -5,14 +5,24 @@
 package sdk
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 )
 
+// ChecksumHeader - Header holding the SHA-256 of a downloaded snapshot
+const ChecksumHeader = "X-Checksum-Sha256"
+
 // CreateSnapshot - Creates a new Snapshot
-func (c *Client) CreateSnapshot(snapshot Snapshot) (*Snapshot, error) {
+func (c *Client) CreateSnapshot(ctx context.Context, snapshot Snapshot) (*Snapshot, error) {
 
 	snapshot.TeamId = snapshot.Team.ID
 
@@ -22,7 +32,8 @@ func (c *Client) CreateSnapshot(snapshot Snapshot) (*Snapshot, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"POST",
 		fmt.Sprintf("%s/snapshot", c.ApiURL),
 		strings.NewReader(string(rb)),
@@ -52,9 +63,10 @@ func (c *Client) CreateSnapshot(snapshot Snapshot) (*Snapshot, error) {
 }
 
 // GetSnapshot - Gets a new Snapshot
-func (c *Client) GetSnapshot(snapshotId string) (*Snapshot, error) {
+func (c *Client) GetSnapshot(ctx context.Context, snapshotId string) (*Snapshot, error) {
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("%s/snapshot/%s", c.ApiURL, snapshotId),
 		nil,
@@ -84,9 +96,10 @@ func (c *Client) GetSnapshot(snapshotId string) (*Snapshot, error) {
 }
 
 // DeleteSnapshot - Deletes a Snapshot
-func (c *Client) DeleteSnapshot(snapshotId string) error {
+func (c *Client) DeleteSnapshot(ctx context.Context, snapshotId string) error {
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"DELETE",
 		fmt.Sprintf("%s/snapshot/%s", c.ApiURL, snapshotId),
 		nil,
@@ -104,3 +117,231 @@ func (c *Client) DeleteSnapshot(snapshotId string) error {
 
 	return nil
 }
+
+// ListSnapshots - Lists snapshots
+func (c *Client) ListSnapshots(ctx context.Context, opts ListOptions) (*SnapshotList, error) {
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s/snapshot?%s", c.ApiURL, opts.query()),
+		nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list := SnapshotList{}
+
+	err = json.Unmarshal(body, &list)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range list.Snapshots {
+		list.Snapshots[i].TeamId = list.Snapshots[i].Team.ID
+	}
+
+	return &list, nil
+}
+
+// RestoreSnapshot - Restores a Snapshot into a record, the restore runs
+// in the background as a Task
+func (c *Client) RestoreSnapshot(ctx context.Context, snapshotId string, restore SnapshotRestore) (*Task, error) {
+
+	rb, err := json.Marshal(restore)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"POST",
+		fmt.Sprintf("%s/snapshot/%s/restore", c.ApiURL, snapshotId),
+		strings.NewReader(string(rb)),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	task := Task{}
+
+	err = json.Unmarshal(body, &task)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// DownloadSnapshot - Downloads the Snapshot data into w. The payload is
+// streamed and hashed on the fly, when the server sends a checksum header
+// the hash is verified against it
+func (c *Client) DownloadSnapshot(ctx context.Context, snapshotId string, w io.Writer) (*SnapshotDownload, error) {
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s/snapshot/%s/download", c.ApiURL, snapshotId),
+		nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.doStream(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	hash := sha256.New()
+
+	size, err := io.Copy(io.MultiWriter(w, hash), res.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	download := SnapshotDownload{
+		Size:   size,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}
+
+	expected := res.Header.Get(ChecksumHeader)
+
+	if expected != "" && !strings.EqualFold(expected, download.SHA256) {
+		return nil, fmt.Errorf("snapshot %s checksum mismatch, expected %s got %s", snapshotId, expected, download.SHA256)
+	}
+
+	return &download, nil
+}
+
+// UploadSnapshot - Creates a new Snapshot from an archive, like one
+// downloaded with DownloadSnapshot. The file is streamed as a multipart
+// form and opened again when the request is retried
+func (c *Client) UploadSnapshot(ctx context.Context, snapshot Snapshot, file string) (*Snapshot, error) {
+
+	snapshot.TeamId = snapshot.Team.ID
+
+	rb, err := json.Marshal(snapshot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+
+	open := func() (io.ReadCloser, error) {
+		source, err := os.Open(file)
+
+		if err != nil {
+			return nil, err
+		}
+
+		reader, writer := io.Pipe()
+
+		go func() {
+			defer source.Close()
+
+			writer.CloseWithError(writeSnapshotForm(writer, boundary, rb, source, filepath.Base(file)))
+		}()
+
+		return reader, nil
+	}
+
+	// Open the file before sending, so a missing file fails early
+	content, err := open()
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"POST",
+		fmt.Sprintf("%s/snapshot/upload", c.ApiURL),
+		nil,
+	)
+
+	if err != nil {
+		content.Close()
+		return nil, err
+	}
+
+	req.Body = content
+	req.GetBody = open
+	req.Header.Set("Content-Type", "multipart/form-data; boundary="+boundary)
+
+	res, err := c.doStream(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	snapshot = Snapshot{}
+
+	err = json.NewDecoder(res.Body).Decode(&snapshot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.TeamId = snapshot.Team.ID
+
+	return &snapshot, nil
+}
+
+// writeSnapshotForm writes the snapshot metadata and the archive as a
+// multipart form
+func writeSnapshotForm(w io.Writer, boundary string, metadata []byte, source io.Reader, name string) error {
+	form := multipart.NewWriter(w)
+
+	if err := form.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	field, err := form.CreateFormField("snapshot")
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := field.Write(metadata); err != nil {
+		return err
+	}
+
+	part, err := form.CreateFormFile("file", name)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(part, source); err != nil {
+		return err
+	}
+
+	return form.Close()
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultTaskPollMin - First wait between two polls of a task
+	DefaultTaskPollMin = 500 * time.Millisecond
+
+	// DefaultTaskPollMax - Maximum wait between two polls of a task
+	DefaultTaskPollMax = 10 * time.Second
+)
+
+// TaskError - Returned when a task finishes with a failure
+type TaskError struct {
+	Task Task
+}
+
+// Error returns the task failure
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("task %s failed: %s", e.Task.ID, e.Task.Result)
+}
+
+// GetTask - Gets a Task
+func (c *Client) GetTask(ctx context.Context, taskId string) (*Task, error) {
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s/task/%s", c.ApiURL, taskId),
+		nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	task := Task{}
+
+	err = json.Unmarshal(body, &task)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// IsTaskDone - Checks if a task status is final
+func IsTaskDone(status string) bool {
+	return status == TaskSuccess || status == TaskFailure
+}
+
+// WaitForTask - Polls a task until it succeeds, fails or the context is
+// done. The last known task is returned with the error
+func WaitForTask(ctx context.Context, api LynxAPI, taskId string) (*Task, error) {
+	var task *Task
+
+	err := poll(ctx, func() (string, error) {
+		var err error
+
+		task, err = api.GetTask(ctx, taskId)
+
+		if err != nil {
+			return "", err
+		}
+
+		return task.Status, nil
+	})
+
+	if err != nil {
+		return task, fmt.Errorf("task %s: %w", taskId, err)
+	}
+
+	if task.Status == TaskFailure {
+		return task, &TaskError{Task: *task}
+	}
+
+	return task, nil
+}
+
+// WaitForSnapshot - Polls a snapshot until its task succeeds, fails or the
+// context is done. The last known snapshot is returned with the error.
+// Snapshots without a status are ready, older Lynx versions take them
+// synchronously
+func WaitForSnapshot(ctx context.Context, api LynxAPI, snapshotId string) (*Snapshot, error) {
+	var snapshot *Snapshot
+
+	err := poll(ctx, func() (string, error) {
+		var err error
+
+		snapshot, err = api.GetSnapshot(ctx, snapshotId)
+
+		if err != nil {
+			return "", err
+		}
+
+		if snapshot.Status == "" {
+			return TaskSuccess, nil
+		}
+
+		return snapshot.Status, nil
+	})
+
+	if err != nil {
+		return snapshot, fmt.Errorf("snapshot %s: %w", snapshotId, err)
+	}
+
+	if snapshot.Status != TaskFailure {
+		return snapshot, nil
+	}
+
+	// The task holds the reason of the failure
+	if snapshot.TaskID != "" {
+		if task, err := api.GetTask(ctx, snapshot.TaskID); err == nil {
+			return snapshot, &TaskError{Task: *task}
+		}
+	}
+
+	return snapshot, fmt.Errorf("snapshot %s failed", snapshotId)
+}
+
+// poll calls fetch until it returns a final status. The wait between
+// calls doubles from DefaultTaskPollMin up to DefaultTaskPollMax
+func poll(ctx context.Context, fetch func() (string, error)) error {
+	wait := DefaultTaskPollMin
+
+	for {
+		status, err := fetch()
+
+		if err != nil {
+			return err
+		}
+
+		if IsTaskDone(status) {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("still %s: %w", status, ctx.Err())
+		case <-timer.C:
+		}
+
+		wait = min(wait*2, DefaultTaskPollMax)
+	}
+}
@@ -5,6 +5,7 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -12,7 +13,7 @@ import (
 )
 
 // CreateTeam - Creates a new Team
-func (c *Client) CreateTeam(team Team) (*Team, error) {
+func (c *Client) CreateTeam(ctx context.Context, team Team) (*Team, error) {
 
 	rb, err := json.Marshal(team)
 
@@ -20,7 +21,8 @@ func (c *Client) CreateTeam(team Team) (*Team, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"POST",
 		fmt.Sprintf("%s/team", c.ApiURL),
 		strings.NewReader(string(rb)),
@@ -48,15 +50,24 @@ func (c *Client) CreateTeam(team Team) (*Team, error) {
 }
 
 // UpdateTeam - Updates a new Team
-func (c *Client) UpdateTeam(team Team) (*Team, error) {
+func (c *Client) UpdateTeam(ctx context.Context, team Team) (*Team, error) {
 
-	rb, err := json.Marshal(team)
+	// Always send the description and the members, omitted fields would
+	// keep their current value on the API
+	payload := teamUpdate{Team: team, Description: team.Description, Members: team.Members}
+
+	if payload.Members == nil {
+		payload.Members = []string{}
+	}
+
+	rb, err := json.Marshal(payload)
 
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"PUT",
 		fmt.Sprintf("%s/team/%s", c.ApiURL, team.ID),
 		strings.NewReader(string(rb)),
@@ -84,9 +95,10 @@ func (c *Client) UpdateTeam(team Team) (*Team, error) {
 }
 
 // GetTeam - Gets a new Team
-func (c *Client) GetTeam(teamId string) (*Team, error) {
+func (c *Client) GetTeam(ctx context.Context, teamId string) (*Team, error) {
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("%s/team/%s", c.ApiURL, teamId),
 		nil,
@@ -114,9 +126,10 @@ func (c *Client) GetTeam(teamId string) (*Team, error) {
 }
 
 // DeleteTeam - Deletes a Team
-func (c *Client) DeleteTeam(teamId string) error {
+func (c *Client) DeleteTeam(ctx context.Context, teamId string) error {
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"DELETE",
 		fmt.Sprintf("%s/team/%s", c.ApiURL, teamId),
 		nil,
@@ -134,3 +147,34 @@ func (c *Client) DeleteTeam(teamId string) error {
 
 	return nil
 }
+
+// ListTeams - Lists teams
+func (c *Client) ListTeams(ctx context.Context, opts ListOptions) (*TeamList, error) {
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s/team?%s", c.ApiURL, opts.query()),
+		nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list := TeamList{}
+
+	err = json.Unmarshal(body, &list)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
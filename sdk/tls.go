@@ -0,0 +1,63 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// LoadCACertPool - Builds a certificate pool from the system roots plus
+// the CA certificates found in the given file and PEM content
+func LoadCACertPool(caCertFile, caCertPEM string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if caCertFile != "" {
+		content, err := os.ReadFile(caCertFile)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA certificate file %s: %w", caCertFile, err)
+		}
+
+		if !pool.AppendCertsFromPEM(content) {
+			return nil, fmt.Errorf("no valid PEM encoded certificate found in %s", caCertFile)
+		}
+	}
+
+	if caCertPEM != "" {
+		if !pool.AppendCertsFromPEM([]byte(caCertPEM)) {
+			return nil, fmt.Errorf("no valid PEM encoded certificate found in the CA certificate content")
+		}
+	}
+
+	return pool, nil
+}
+
+// LoadClientCertificate - Loads a client certificate and its private key
+// used for mutual TLS
+func LoadClientCertificate(certFile, keyFile string) (tls.Certificate, error) {
+	if certFile == "" || keyFile == "" {
+		return tls.Certificate{}, fmt.Errorf("both the client certificate and the client key files are required")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to load client certificate %s and key %s: %w", certFile, keyFile, err)
+	}
+
+	return cert, nil
+}
+
+// SetTLSConfig - Sets the TLS configuration of the client transport
+func (c *Client) SetTLSConfig(config *tls.Config) {
+	c.transport().TLSClientConfig = config
+}
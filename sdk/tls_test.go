@@ -0,0 +1,209 @@
+// Copyright 2024 Clivern. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sdk_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/clivern/terraform-provider-lynx/sdk"
+	"github.com/clivern/terraform-provider-lynx/sdk/lynxtest"
+)
+
+// TestUnitLoadCACertPool tests loading the CA certificates
+func TestUnitLoadCACertPool(t *testing.T) {
+	dir := t.TempDir()
+	ca := testCA(t)
+	leaf := testIssue(t, ca, "127.0.0.1")
+	caFile, _ := testWriteFiles(t, ca, dir, "ca")
+	invalidFile := filepath.Join(dir, "invalid.pem")
+
+	if err := os.WriteFile(invalidFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+
+	for _, test := range []struct {
+		name    string
+		file    string
+		content string
+		err     string
+	}{
+		{name: "file", file: caFile},
+		{name: "content", content: string(ca.CertPEM)},
+		{name: "missing file", file: filepath.Join(dir, "missing.pem"), err: "unable to read CA certificate file"},
+		{name: "unreadable file", file: dir, err: "unable to read CA certificate file"},
+		{name: "invalid file", file: invalidFile, err: "no valid PEM encoded certificate found in " + invalidFile},
+		{name: "invalid content", content: "not a certificate", err: "no valid PEM encoded certificate found in the CA certificate content"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pool, err := sdk.LoadCACertPool(test.file, test.content)
+
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("expected an error containing %q, got: %v", test.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unable to load CA: %s", err)
+			}
+
+			if _, err := leaf.Certificate.Verify(x509.VerifyOptions{Roots: pool}); err != nil {
+				t.Errorf("expected the CA to be trusted, got: %s", err)
+			}
+		})
+	}
+}
+
+// TestUnitLoadClientCertificate tests loading the mTLS client certificate
+func TestUnitLoadClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := testCA(t)
+	certFile, keyFile := testWriteFiles(t, testIssue(t, ca, "client"), dir, "client")
+	_, otherKeyFile := testWriteFiles(t, testIssue(t, ca, "other"), dir, "other")
+
+	for _, test := range []struct {
+		name     string
+		certFile string
+		keyFile  string
+		err      string
+	}{
+		{name: "pair", certFile: certFile, keyFile: keyFile},
+		{name: "mismatched key", certFile: certFile, keyFile: otherKeyFile, err: "private key does not match public key"},
+		{name: "missing key", certFile: certFile, err: "both the client certificate and the client key files are required"},
+		{name: "missing certificate", keyFile: keyFile, err: "both the client certificate and the client key files are required"},
+		{name: "key as certificate", certFile: keyFile, keyFile: keyFile, err: "unable to load client certificate"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cert, err := sdk.LoadClientCertificate(test.certFile, test.keyFile)
+
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("expected an error containing %q, got: %v", test.err, err)
+				}
+
+				return
+			}
+
+			if err != nil || len(cert.Certificate) != 1 {
+				t.Errorf("unable to load client certificate: %v", err)
+			}
+		})
+	}
+}
+
+// TestUnitClientMutualTLS tests the client against a server requiring
+// a client certificate
+func TestUnitClientMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := testCA(t)
+	caFile, _ := testWriteFiles(t, ca, dir, "ca")
+	certFile, keyFile := testWriteFiles(t, testIssue(t, ca, "client"), dir, "client")
+
+	serverCert, err := testIssue(t, ca, "127.0.0.1").TLSCertificate()
+
+	if err != nil {
+		t.Fatalf("unable to load server certificate: %s", err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.Certificate)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"version": "%s"}`, r.TLS.PeerCertificates[0].Subject.CommonName)
+	}))
+
+	// Keep the rejected handshake out of the test output
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+
+	server.StartTLS()
+	defer server.Close()
+
+	pool, err := sdk.LoadCACertPool(caFile, "")
+
+	if err != nil {
+		t.Fatalf("unable to load CA: %s", err)
+	}
+
+	cert, err := sdk.LoadClientCertificate(certFile, keyFile)
+
+	if err != nil {
+		t.Fatalf("unable to load client certificate: %s", err)
+	}
+
+	client := sdk.NewClient(server.URL, "secret-key")
+	client.MaxRetries = 0
+
+	// Without a client certificate the handshake fails
+	client.SetTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool})
+
+	if _, err := client.GetServerInfo(context.Background()); err == nil {
+		t.Error("expected the server to reject the client without certificate")
+	}
+
+	client = sdk.NewClient(server.URL, "secret-key")
+	client.MaxRetries = 0
+	client.SetTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool, Certificates: []tls.Certificate{cert}})
+
+	info, err := client.GetServerInfo(context.Background())
+
+	if err != nil {
+		t.Fatalf("unable to get server info: %s", err)
+	}
+
+	if info.Version != "Lynx Test" {
+		t.Errorf("expected the server to see the client certificate, got: %s", info.Version)
+	}
+}
+
+// testCA generates a certificate authority
+func testCA(t *testing.T) *lynxtest.Certificate {
+	ca, err := lynxtest.NewCA()
+
+	if err != nil {
+		t.Fatalf("unable to generate CA: %s", err)
+	}
+
+	return ca
+}
+
+// testIssue generates a certificate signed by the CA
+func testIssue(t *testing.T, ca *lynxtest.Certificate, hosts ...string) *lynxtest.Certificate {
+	cert, err := ca.Issue(hosts...)
+
+	if err != nil {
+		t.Fatalf("unable to issue certificate: %s", err)
+	}
+
+	return cert
+}
+
+// testWriteFiles writes the certificate and its key and returns their paths
+func testWriteFiles(t *testing.T, cert *lynxtest.Certificate, dir, name string) (string, string) {
+	certFile, keyFile, err := cert.WriteFiles(dir, name)
+
+	if err != nil {
+		t.Fatalf("unable to write certificate: %s", err)
+	}
+
+	return certFile, keyFile
+}
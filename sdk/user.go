@@ -5,6 +5,7 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -12,7 +13,7 @@ import (
 )
 
 // CreateUser - Creates a new User
-func (c *Client) CreateUser(user User) (*User, error) {
+func (c *Client) CreateUser(ctx context.Context, user User) (*User, error) {
 
 	rb, err := json.Marshal(user)
 
@@ -20,7 +21,8 @@ func (c *Client) CreateUser(user User) (*User, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"POST",
 		fmt.Sprintf("%s/user", c.ApiURL),
 		strings.NewReader(string(rb)),
@@ -48,7 +50,7 @@ func (c *Client) CreateUser(user User) (*User, error) {
 }
 
 // UpdateUser - Updates a new User
-func (c *Client) UpdateUser(user User) (*User, error) {
+func (c *Client) UpdateUser(ctx context.Context, user User) (*User, error) {
 
 	rb, err := json.Marshal(user)
 
@@ -56,7 +58,8 @@ func (c *Client) UpdateUser(user User) (*User, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"PUT",
 		fmt.Sprintf("%s/user/%s", c.ApiURL, user.ID),
 		strings.NewReader(string(rb)),
@@ -84,9 +87,10 @@ func (c *Client) UpdateUser(user User) (*User, error) {
 }
 
 // GetUser - Gets a new User
-func (c *Client) GetUser(userId string) (*User, error) {
+func (c *Client) GetUser(ctx context.Context, userId string) (*User, error) {
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("%s/user/%s", c.ApiURL, userId),
 		nil,
@@ -114,9 +118,10 @@ func (c *Client) GetUser(userId string) (*User, error) {
 }
 
 // DeleteUser - Deletes a User
-func (c *Client) DeleteUser(userId string) error {
+func (c *Client) DeleteUser(ctx context.Context, userId string) error {
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"DELETE",
 		fmt.Sprintf("%s/user/%s", c.ApiURL, userId),
 		nil,
@@ -134,3 +139,34 @@ func (c *Client) DeleteUser(userId string) error {
 
 	return nil
 }
+
+// ListUsers - Lists users
+func (c *Client) ListUsers(ctx context.Context, opts ListOptions) (*UserList, error) {
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s/user?%s", c.ApiURL, opts.query()),
+		nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list := UserList{}
+
+	err = json.Unmarshal(body, &list)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}